@@ -0,0 +1,288 @@
+package imagerelocate
+
+import (
+	"encoding/json"
+	"errors"
+	"image"
+	"image/draw"
+	"math"
+	"sort"
+)
+
+var errAtlasTooSmall = errors.New("imagerelocate: images do not fit in the maximum atlas size")
+
+// AtlasOptions are the options for NewAtlas.
+//
+// ‘Padding’ is how many (transparent) pixels are left between packed images.
+//
+// ‘Margin’ is how many (transparent) pixels are left between the packed images and the edge of the atlas.
+//
+// ‘MaxWidth’ and ‘MaxHeight’ limit the size of the atlas. If ‘MaxWidth’ is zero, a width is picked
+// to make the atlas roughly square. If ‘MaxHeight’ is zero, the atlas is as tall as it needs to be.
+type AtlasOptions struct {
+	Padding   int
+	Margin    int
+	MaxWidth  int
+	MaxHeight int
+}
+
+// AtlasEntry is where one of the images given to NewAtlas was packed.
+//
+// ‘Rect’ is where in the atlas the image was packed.
+//
+// ‘Image’ is a view into the atlas of just that image, relocated back to the bounds of the original image.
+// So it can be used in place of the original image.
+type AtlasEntry struct {
+	Rect  image.Rectangle
+	Image image.Image
+}
+
+// Atlas is a texture atlas — many images packed into one.
+//
+// ‘Entries’ are in the same order as the images given to NewAtlas.
+//
+// An Atlas can be passed to json.Marshal to get metadata describing where each image was packed.
+type Atlas struct {
+	Image   *image.RGBA
+	Entries []AtlasEntry
+}
+
+// NewAtlas packs ‘images’ into a texture atlas, using the MaxRects bin-packing algorithm (with the best-short-side-fit heuristic).
+//
+// Packing is deterministic — the same images and options always result in the same atlas.
+//
+// An error is returned if the images do not fit within ‘MaxWidth’ and ‘MaxHeight’.
+func NewAtlas(images []image.Image, opts AtlasOptions) (*Atlas, error) {
+	padding := max(0, opts.Padding)
+	margin := max(0, opts.Margin)
+
+	sizes := make([]image.Point, len(images))
+	order := make([]int, len(images))
+
+	var area int
+	var widest int
+	for i, img := range images {
+		sizes[i] = img.Bounds().Size()
+		order[i] = i
+
+		area += (sizes[i].X+padding) * (sizes[i].Y+padding)
+		widest = max(widest, sizes[i].X)
+	}
+
+	// Packing the biggest images first packs better.
+	// (The sort is stable, so equal sized images stay in their original order, which keeps packing deterministic.)
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := sizes[order[i]], sizes[order[j]]
+
+		if max(a.X,a.Y) != max(b.X,b.Y) {
+			return max(a.X,a.Y) > max(b.X,b.Y)
+		}
+		return a.X*a.Y > b.X*b.Y
+	})
+
+	width := opts.MaxWidth
+	if width <= 0 {
+		width = max(widest, int(math.Ceil(math.Sqrt(float64(area))))) + 2*margin
+	}
+
+	height := opts.MaxHeight
+	if height <= 0 {
+		// Tall enough for anything.
+		height = 2*margin
+		for _, size := range sizes {
+			height += size.Y + padding
+		}
+	}
+
+	// The padding is packed along with each image; so that the last row and column do not need padding,
+	// the bin is made larger by the padding.
+	packer := maxRectsPacker{
+		free: []image.Rectangle{
+			image.Rect(0,0, width-2*margin+padding, height-2*margin+padding),
+		},
+	}
+
+	rects := make([]image.Rectangle, len(images))
+
+	var used image.Rectangle
+	for _, i := range order {
+		if sizes[i].X <= 0 || sizes[i].Y <= 0 {
+			rects[i] = image.Rectangle{Min:image.Point{margin,margin}, Max:image.Point{margin,margin}}
+			continue
+		}
+
+		min, ok := packer.insert(sizes[i].Add(image.Point{padding,padding}))
+		if !ok {
+			return nil, errAtlasTooSmall
+		}
+
+		min = min.Add(image.Point{margin,margin})
+		rects[i] = image.Rectangle{Min:min, Max:min.Add(sizes[i])}
+		used = used.Union(rects[i])
+	}
+
+	if opts.MaxHeight <= 0 {
+		height = used.Max.Y + margin
+	}
+	if opts.MaxWidth <= 0 {
+		width = used.Max.X + margin
+	}
+
+	atlas := &Atlas{
+		Image:   image.NewRGBA(image.Rect(0,0, width,height)),
+		Entries: make([]AtlasEntry, len(images)),
+	}
+
+	for i, img := range images {
+		bounds := img.Bounds()
+		rect := rects[i]
+
+		Draw(atlas.Image, rect, img, bounds.Min, draw.Src)
+
+		view := atlas.Image.SubImage(rect)
+		delta := bounds.Min.Sub(rect.Min)
+
+		atlas.Entries[i] = AtlasEntry{
+			Rect:  rect,
+			Image: Wrap(delta.X, delta.Y, view),
+		}
+	}
+
+	return atlas, nil
+}
+
+// MarshalJSON returns JSON metadata describing where each image was packed. For example:
+//
+//	{
+//		"width": 256,
+//		"height": 128,
+//		"frames": [
+//			{"x": 0, "y": 0, "w": 32, "h": 32},
+//			{"x": 33, "y": 0, "w": 16, "h": 48}
+//		]
+//	}
+func (receiver Atlas) MarshalJSON() ([]byte, error) {
+	type frame struct {
+		X int `json:"x"`
+		Y int `json:"y"`
+		W int `json:"w"`
+		H int `json:"h"`
+	}
+
+	var metadata struct {
+		Width  int     `json:"width"`
+		Height int     `json:"height"`
+		Frames []frame `json:"frames"`
+	}
+
+	if nil != receiver.Image {
+		metadata.Width = receiver.Image.Rect.Dx()
+		metadata.Height = receiver.Image.Rect.Dy()
+	}
+
+	metadata.Frames = make([]frame, len(receiver.Entries))
+	for i, entry := range receiver.Entries {
+		metadata.Frames[i] = frame{
+			X: entry.Rect.Min.X,
+			Y: entry.Rect.Min.Y,
+			W: entry.Rect.Dx(),
+			H: entry.Rect.Dy(),
+		}
+	}
+
+	return json.Marshal(metadata)
+}
+
+// maxRectsPacker is the MaxRects bin-packing algorithm.
+//
+// It keeps track of every maximal free rectangle, even though they overlap.
+type maxRectsPacker struct {
+	free []image.Rectangle
+}
+
+// insert finds a place for a rectangle of size ‘size’, using the best-short-side-fit heuristic, and marks it as used.
+//
+// Ties are broken by the best long side fit, then the top-most, then the left-most — so packing is deterministic.
+func (receiver *maxRectsPacker) insert(size image.Point) (image.Point, bool) {
+	var best image.Point
+	var bestShort, bestLong = math.MaxInt, math.MaxInt
+	var found bool
+
+	for _, free := range receiver.free {
+		leftoverX := free.Dx() - size.X
+		leftoverY := free.Dy() - size.Y
+		if leftoverX < 0 || leftoverY < 0 {
+			continue
+		}
+
+		short := min(leftoverX, leftoverY)
+		long := max(leftoverX, leftoverY)
+
+		better := short < bestShort ||
+			(short == bestShort && long < bestLong) ||
+			(short == bestShort && long == bestLong && (free.Min.Y < best.Y || (free.Min.Y == best.Y && free.Min.X < best.X)))
+
+		if better {
+			best = free.Min
+			bestShort, bestLong = short, long
+			found = true
+		}
+	}
+
+	if !found {
+		return image.Point{}, false
+	}
+
+	receiver.use(image.Rectangle{Min:best, Max:best.Add(size)})
+
+	return best, true
+}
+
+// use splits every free rectangle that ‘used’ overlaps into the (up to 4) maximal free rectangles around it,
+// and then removes any free rectangle that is inside of another.
+func (receiver *maxRectsPacker) use(used image.Rectangle) {
+	var free []image.Rectangle
+
+	for _, r := range receiver.free {
+		if !r.Overlaps(used) {
+			free = append(free, r)
+			continue
+		}
+
+		if r.Min.X < used.Min.X {
+			free = append(free, image.Rect(r.Min.X, r.Min.Y, used.Min.X, r.Max.Y))
+		}
+		if used.Max.X < r.Max.X {
+			free = append(free, image.Rect(used.Max.X, r.Min.Y, r.Max.X, r.Max.Y))
+		}
+		if r.Min.Y < used.Min.Y {
+			free = append(free, image.Rect(r.Min.X, r.Min.Y, r.Max.X, used.Min.Y))
+		}
+		if used.Max.Y < r.Max.Y {
+			free = append(free, image.Rect(r.Min.X, used.Max.Y, r.Max.X, r.Max.Y))
+		}
+	}
+
+	// Remove the free rectangles that are inside of another.
+	// (Of identical free rectangles, only the first is kept.)
+	var pruned []image.Rectangle
+	for i, r := range free {
+		contained := false
+		for j, other := range free {
+			if i == j || !r.In(other) {
+				continue
+			}
+			if r == other && i < j {
+				continue
+			}
+			contained = true
+			break
+		}
+
+		if !contained {
+			pruned = append(pruned, r)
+		}
+	}
+
+	receiver.free = pruned
+}
@@ -0,0 +1,151 @@
+package imagerelocate_test
+
+import (
+	"github.com/reiver/go-imagerelocate"
+
+	"encoding/json"
+	"image"
+	"math/rand"
+	"reflect"
+
+	"testing"
+)
+
+func TestNewAtlas(t *testing.T) {
+
+	randomness := rand.New(rand.NewSource(1))
+
+	var images []image.Image
+	for i:=0; i<40; i++ {
+		x, y := randomness.Intn(100)-50, randomness.Intn(100)-50
+		img := image.NewRGBA(image.Rect(x,y, x+1+randomness.Intn(30), y+1+randomness.Intn(30)))
+		for i := range img.Pix {
+			img.Pix[i] = uint8(randomness.Intn(256))
+		}
+		images = append(images, img)
+	}
+
+	tests := []struct{
+		Options imagerelocate.AtlasOptions
+	}{
+		{
+			Options: imagerelocate.AtlasOptions{},
+		},
+		{
+			Options: imagerelocate.AtlasOptions{
+				Padding: 2,
+				Margin: 1,
+			},
+		},
+		{
+			Options: imagerelocate.AtlasOptions{
+				Padding: 1,
+				MaxWidth: 64,
+			},
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		atlas, err := imagerelocate.NewAtlas(images, test.Options)
+		if nil != err {
+			t.Errorf("For test #%d, did not expect an error but actually got one.", testNumber)
+			t.Logf("ERROR: (%T) %s", err, err)
+			continue
+		}
+
+		if expected, actual := len(images), len(atlas.Entries); expected != actual {
+			t.Errorf("For test #%d, the actual number of entries is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %d", expected)
+			t.Logf("ACTUAL:   %d", actual)
+			continue
+		}
+
+		if 0 < test.Options.MaxWidth && test.Options.MaxWidth < atlas.Image.Rect.Dx() {
+			t.Errorf("For test #%d, the atlas is wider than the maximum width.", testNumber)
+			t.Logf("WIDTH: %d", atlas.Image.Rect.Dx())
+			continue
+		}
+
+		for i, entry := range atlas.Entries {
+
+			if !entry.Rect.In(atlas.Image.Rect.Inset(test.Options.Margin)) {
+				t.Errorf("For test #%d, entry #%d is not inside of the atlas (and its margin).", testNumber, i)
+				t.Logf("RECT: %#v", entry.Rect)
+				continue
+			}
+
+			for j, other := range atlas.Entries[i+1:] {
+				if entry.Rect.Inset(-test.Options.Padding).Overlaps(other.Rect) {
+					t.Errorf("For test #%d, entry #%d and entry #%d are not padded apart.", testNumber, i, i+1+j)
+					t.Logf("RECT: %#v", entry.Rect)
+					t.Logf("RECT: %#v", other.Rect)
+				}
+			}
+
+			bounds := images[i].Bounds()
+
+			if expected, actual := bounds, entry.Image.Bounds(); expected != actual {
+				t.Errorf("For test #%d, the actual bounds of entry #%d are not what was expected.", testNumber, i)
+				t.Logf("EXPECTED: %#v", expected)
+				t.Logf("ACTUAL:   %#v", actual)
+				continue
+			}
+
+			for y:=bounds.Min.Y; y<bounds.Max.Y; y++ {
+				for x:=bounds.Min.X; x<bounds.Max.X; x++ {
+					if expected, actual := images[i].At(x,y), entry.Image.At(x,y); expected != actual {
+						t.Errorf("For test #%d, the actual color of entry #%d at (%d,%d) is not what was expected.", testNumber, i, x,y)
+						t.Logf("EXPECTED: %#v", expected)
+						t.Logf("ACTUAL:   %#v", actual)
+						return
+					}
+				}
+			}
+		}
+
+		// Packing should be deterministic.
+		{
+			again, err := imagerelocate.NewAtlas(images, test.Options)
+			if nil != err {
+				t.Errorf("For test #%d, did not expect an error but actually got one.", testNumber)
+				t.Logf("ERROR: (%T) %s", err, err)
+				continue
+			}
+
+			expected, err := json.Marshal(atlas)
+			if nil != err {
+				t.Errorf("For test #%d, did not expect an error but actually got one.", testNumber)
+				t.Logf("ERROR: (%T) %s", err, err)
+				continue
+			}
+
+			actual, err := json.Marshal(again)
+			if nil != err {
+				t.Errorf("For test #%d, did not expect an error but actually got one.", testNumber)
+				t.Logf("ERROR: (%T) %s", err, err)
+				continue
+			}
+
+			if !reflect.DeepEqual(expected, actual) {
+				t.Errorf("For test #%d, packing the same images twice did not result in the same atlas.", testNumber)
+				t.Logf("EXPECTED: %s", expected)
+				t.Logf("ACTUAL:   %s", actual)
+				continue
+			}
+		}
+	}
+}
+
+func TestNewAtlas_tooSmall(t *testing.T) {
+
+	images := []image.Image{
+		image.NewRGBA(image.Rect(0,0, 10,10)),
+		image.NewRGBA(image.Rect(0,0, 10,10)),
+	}
+
+	_, err := imagerelocate.NewAtlas(images, imagerelocate.AtlasOptions{MaxWidth:15, MaxHeight:15})
+	if nil == err {
+		t.Errorf("Expected an error but did not actually get one.")
+	}
+}
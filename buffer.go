@@ -0,0 +1,155 @@
+package imagerelocate
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+// Pixel is the constraint for the pixel type of a Buffer.
+//
+// A pixel type converts itself to a color.RGBA64 (alpha-premultiplied), and makes a new pixel from a color.RGBA64.
+// For example:
+//
+//	type Gray4 uint8
+//
+//	func (receiver Gray4) RGBA64() color.RGBA64 {
+//		v := uint16(receiver) * 0x1111
+//		return color.RGBA64{v,v,v,0xffff}
+//	}
+//
+//	func (Gray4) FromRGBA64(c color.RGBA64) Gray4 {
+//		return Gray4(color.GrayModel.Convert(c).(color.Gray).Y >> 4)
+//	}
+//
+// (.FromRGBA64() should not depend on the value it is called on.)
+//
+// See RGB565 for another example.
+type Pixel[P any] interface {
+	RGBA64() color.RGBA64
+	FromRGBA64(color.RGBA64) P
+}
+
+// Buffer is an image whose pixels are stored as a slice of a custom pixel type ‘P’.
+//
+// ‘Pix’ holds the pixels; the pixel at (x,y) is at Pix[(y-Rect.Min.Y)*Stride + (x-Rect.Min.X)].
+//
+// ‘Stride’ is the ‘Pix’ stride (in pixels, not bytes) between vertically adjacent pixels.
+//
+// ‘Rect’ is the bounds. Its .Min is the origin, and can be changed (see .SetOrigin()) to relocate the
+// buffer in constant time, without touching ‘Pix’.
+//
+// Buffer is an image.Image, draw.Image, and image.RGBA64Image.
+// Wrap, when given a *Buffer, returns a *Buffer (sharing ‘Pix’) with its origin moved.
+type Buffer[P Pixel[P]] struct {
+	Pix    []P
+	Stride int
+	Rect   image.Rectangle
+}
+
+var _ image.RGBA64Image = &Buffer[RGB565]{}
+var _ draw.RGBA64Image = &Buffer[RGB565]{}
+
+// NewBuffer returns a new Buffer with the given bounds.
+func NewBuffer[P Pixel[P]](r image.Rectangle) *Buffer[P] {
+	return &Buffer[P]{
+		Pix:    make([]P, r.Dx()*r.Dy()),
+		Stride: r.Dx(),
+		Rect:   r,
+	}
+}
+
+func (receiver *Buffer[P]) At(x, y int) color.Color {
+	return receiver.RGBA64At(x,y)
+}
+
+func (receiver *Buffer[P]) Bounds() image.Rectangle {
+	return receiver.Rect
+}
+
+// ColorModel returns a color.Model that converts colors to what ‘P’ can represent (returned as a color.RGBA64).
+func (receiver *Buffer[P]) ColorModel() color.Model {
+	return color.ModelFunc(func(c color.Color) color.Color {
+		var p P
+		return p.FromRGBA64(toRGBA64(c)).RGBA64()
+	})
+}
+
+// PixOffset returns the index of the pixel at (x,y) in ‘Pix’.
+func (receiver *Buffer[P]) PixOffset(x, y int) int {
+	return (y-receiver.Rect.Min.Y)*receiver.Stride + (x-receiver.Rect.Min.X)
+}
+
+// PixelAt returns the pixel at (x,y).
+//
+// If (x,y) is outside of the bounds, the zero value of ‘P’ is returned.
+func (receiver *Buffer[P]) PixelAt(x, y int) P {
+	if !(image.Point{x,y}).In(receiver.Rect) {
+		var p P
+		return p
+	}
+
+	return receiver.Pix[receiver.PixOffset(x,y)]
+}
+
+// SetPixel sets the pixel at (x,y).
+//
+// If (x,y) is outside of the bounds, nothing happens.
+func (receiver *Buffer[P]) SetPixel(x, y int, p P) {
+	if !(image.Point{x,y}).In(receiver.Rect) {
+		return
+	}
+
+	receiver.Pix[receiver.PixOffset(x,y)] = p
+}
+
+func (receiver *Buffer[P]) RGBA64At(x, y int) color.RGBA64 {
+	if !(image.Point{x,y}).In(receiver.Rect) {
+		return color.RGBA64{}
+	}
+
+	return receiver.Pix[receiver.PixOffset(x,y)].RGBA64()
+}
+
+func (receiver *Buffer[P]) Set(x, y int, c color.Color) {
+	receiver.SetRGBA64(x,y, toRGBA64(c))
+}
+
+func (receiver *Buffer[P]) SetRGBA64(x, y int, c color.RGBA64) {
+	if !(image.Point{x,y}).In(receiver.Rect) {
+		return
+	}
+
+	var p P
+	receiver.Pix[receiver.PixOffset(x,y)] = p.FromRGBA64(c)
+}
+
+// SetOrigin moves the buffer, so that its top-left corner is at ‘origin’.
+//
+// This is constant-time; ‘Pix’ is not touched.
+func (receiver *Buffer[P]) SetOrigin(origin image.Point) {
+	receiver.Rect = receiver.Rect.Add(origin.Sub(receiver.Rect.Min))
+}
+
+// Relocated returns a new Buffer, sharing ‘Pix’ with this one, moved by (‘dx’,‘dy’).
+func (receiver *Buffer[P]) Relocated(dx, dy int) *Buffer[P] {
+	relocated := *receiver
+	relocated.Rect = receiver.Rect.Add(image.Point{dx,dy})
+
+	return &relocated
+}
+
+// relocate lets Wrap relocate a *Buffer in constant time.
+func (receiver *Buffer[P]) relocate(dx, dy int) image.Image {
+	return receiver.Relocated(dx,dy)
+}
+
+func toRGBA64(c color.Color) color.RGBA64 {
+	if casted, ok := c.(color.RGBA64); ok {
+		return casted
+	}
+
+	r,g,b,a := c.RGBA()
+
+	return color.RGBA64{R:uint16(r), G:uint16(g), B:uint16(b), A:uint16(a)}
+}
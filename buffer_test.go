@@ -0,0 +1,88 @@
+package imagerelocate_test
+
+import (
+	"github.com/reiver/go-imagerelocate"
+
+	"image"
+	"image/color"
+	"image/draw"
+
+	"testing"
+)
+
+func TestBuffer(t *testing.T) {
+
+	buffer := imagerelocate.NewBuffer[imagerelocate.RGB565](image.Rect(0,0, 4,3))
+
+	tests := []struct{
+		X int
+		Y int
+		Color color.Color
+		Expected color.RGBA64
+	}{
+		{
+			X: 0,
+			Y: 0,
+			Color: color.RGBA{R:255, A:255},
+			Expected: color.RGBA64{R:0xffff, A:0xffff},
+		},
+		{
+			X: 3,
+			Y: 2,
+			Color: color.RGBA{G:255, B:255, A:255},
+			Expected: color.RGBA64{G:0xffff, B:0xffff, A:0xffff},
+		},
+		{
+			X: 1,
+			Y: 2,
+			Color: color.White,
+			Expected: color.RGBA64{R:0xffff, G:0xffff, B:0xffff, A:0xffff},
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		buffer.Set(test.X, test.Y, test.Color)
+
+		if expected, actual := test.Expected, buffer.RGBA64At(test.X, test.Y); expected != actual {
+			t.Errorf("For test #%d, the actual color is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %#v", expected)
+			t.Logf("ACTUAL:   %#v", actual)
+			continue
+		}
+
+		relocated, casted := imagerelocate.Wrap(10,-20, buffer).(*imagerelocate.Buffer[imagerelocate.RGB565])
+		if !casted {
+			t.Errorf("For test #%d, expected the relocated image to be a *Buffer, but it wasn't.", testNumber)
+			continue
+		}
+
+		if expected, actual := image.Rect(10,-20, 14,-17), relocated.Bounds(); expected != actual {
+			t.Errorf("For test #%d, the actual relocated bounds are not what was expected.", testNumber)
+			t.Logf("EXPECTED: %#v", expected)
+			t.Logf("ACTUAL:   %#v", actual)
+			continue
+		}
+
+		if expected, actual := test.Expected, relocated.RGBA64At(test.X+10, test.Y-20); expected != actual {
+			t.Errorf("For test #%d, the actual relocated color is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %#v", expected)
+			t.Logf("ACTUAL:   %#v", actual)
+			continue
+		}
+	}
+
+	// A *Buffer should work as a draw.Image.
+	{
+		dst := imagerelocate.NewBuffer[imagerelocate.RGB565](image.Rect(0,0, 4,3))
+		dst.SetOrigin(image.Pt(100,100))
+
+		draw.Draw(dst, dst.Bounds(), buffer, buffer.Bounds().Min, draw.Src)
+
+		if expected, actual := (color.RGBA64{R:0xffff, A:0xffff}), dst.RGBA64At(100,100); expected != actual {
+			t.Errorf("The actual drawn color is not what was expected.")
+			t.Logf("EXPECTED: %#v", expected)
+			t.Logf("ACTUAL:   %#v", actual)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package imagerelocate
+
+import (
+	"image/color"
+)
+
+// over composites ‘src’ over ‘dst’, using the Porter-Duff "over" operator.
+//
+// Both colors are alpha-premultiplied (as is everything returned from the .RGBA() method of a color.Color).
+func over(dst color.RGBA64, src color.Color) color.RGBA64 {
+	const m = 0xffff
+
+	r,g,b,a := src.RGBA()
+	if 0 == a {
+		return dst
+	}
+	if m == a {
+		return color.RGBA64{R:uint16(r), G:uint16(g), B:uint16(b), A:uint16(a)}
+	}
+
+	ia := m - a
+
+	return color.RGBA64{
+		R: uint16(r + (uint32(dst.R)*ia)/m),
+		G: uint16(g + (uint32(dst.G)*ia)/m),
+		B: uint16(b + (uint32(dst.B)*ia)/m),
+		A: uint16(a + (uint32(dst.A)*ia)/m),
+	}
+}
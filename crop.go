@@ -0,0 +1,52 @@
+package imagerelocate
+
+import (
+	"image"
+	"image/color"
+)
+
+// internalCroppedImage is a view of only the part of an image inside of a rectangle.
+//
+// Coordinates are not changed — a pixel at (x,y) in the cropped image is the pixel at (x,y) in the original image.
+type internalCroppedImage struct {
+	img  image.Image
+	rect image.Rectangle
+}
+
+func (receiver internalCroppedImage) At(x, y int) color.Color {
+	if !(image.Point{x,y}).In(receiver.rect) {
+		return color.Transparent
+	}
+
+	return receiver.img.At(x,y)
+}
+
+func (receiver internalCroppedImage) Bounds() image.Rectangle {
+	return receiver.rect
+}
+
+func (receiver internalCroppedImage) ColorModel() color.Model {
+	return receiver.img.ColorModel()
+}
+
+// subImager is implemented by the concrete image types in Go's built-in "image" package (such as *image.RGBA).
+type subImager interface {
+	SubImage(image.Rectangle) image.Image
+}
+
+// crop returns a view of only the part of ‘img’ inside of ‘r’, keeping its coordinates.
+//
+// If ‘img’ has a .SubImage() method (as the concrete image types in Go's built-in "image" package do), then that is used,
+// so that the result keeps the concrete type of ‘img’.
+func crop(img image.Image, r image.Rectangle) image.Image {
+	r = clip(img, r)
+
+	if casted, ok := img.(subImager); ok {
+		return casted.SubImage(r)
+	}
+
+	return internalCroppedImage{
+		img:  img,
+		rect: r,
+	}
+}
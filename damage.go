@@ -0,0 +1,197 @@
+package imagerelocate
+
+import (
+	"image"
+)
+
+// MergeIfWasteAtMost returns a merge heuristic for a DamageTracker, that merges two dirty rectangles
+// if their bounding box repaints at most ‘waste’ pixels that neither of them needed repainted.
+//
+// With a ‘waste’ of 0, only rectangles whose union is exactly their bounding box (for example, overlapping
+// rectangles of the same height side-by-side) are merged.
+func MergeIfWasteAtMost(waste int) func(a, b image.Rectangle) bool {
+	return func(a, b image.Rectangle) bool {
+		union := a.Union(b)
+		overlap := a.Intersect(b)
+
+		needed := area(a) + area(b) - area(overlap)
+
+		return area(union) - needed <= waste
+	}
+}
+
+// DefaultDamageMerge is the merge heuristic a DamageTracker uses if its ‘Merge’ is nil.
+var DefaultDamageMerge = MergeIfWasteAtMost(256)
+
+// DamageTracker keeps track of what needs repainting as relocated images (layers) are added, moved, removed, or changed —
+// so that a renderer can repaint only what changed.
+//
+// For example, when a sprite moves from offset A to offset B, only its old bounds and its new bounds are dirty.
+//
+// ‘Merge’ decides whether two dirty rectangles are merged into their bounding box
+// (fewer, bigger, rectangles are often faster to repaint than many small ones).
+// If ‘Merge’ is nil, DefaultDamageMerge is used.
+//
+// The zero value is ready to use.
+// A DamageTracker is not safe to use from multiple goroutines at the same time.
+type DamageTracker struct {
+	Merge func(a, b image.Rectangle) bool
+
+	nextID int
+	layers map[int]image.Rectangle
+	dirty  []image.Rectangle
+}
+
+// Add starts tracking ‘img’, marks its bounds as dirty, and returns its ID.
+func (receiver *DamageTracker) Add(img image.Image) int {
+	if nil == receiver.layers {
+		receiver.layers = map[int]image.Rectangle{}
+	}
+
+	id := receiver.nextID
+	receiver.nextID++
+
+	bounds := img.Bounds()
+	receiver.layers[id] = bounds
+	receiver.InvalidateRect(bounds)
+
+	return id
+}
+
+// Move replaces the image with ID ‘id’ with ‘img’ (for example, the same image wrapped with a new offset),
+// and marks both its old bounds and its new bounds as dirty.
+//
+// Move returns false if there is no image with ID ‘id’.
+func (receiver *DamageTracker) Move(id int, img image.Image) bool {
+	old, found := receiver.layers[id]
+	if !found {
+		return false
+	}
+
+	bounds := img.Bounds()
+	if bounds == old {
+		return true
+	}
+
+	receiver.layers[id] = bounds
+	receiver.InvalidateRect(old)
+	receiver.InvalidateRect(bounds)
+
+	return true
+}
+
+// Remove stops tracking the image with ID ‘id’, and marks its bounds as dirty.
+//
+// Remove returns false if there is no image with ID ‘id’.
+func (receiver *DamageTracker) Remove(id int) bool {
+	old, found := receiver.layers[id]
+	if !found {
+		return false
+	}
+
+	delete(receiver.layers, id)
+	receiver.InvalidateRect(old)
+
+	return true
+}
+
+// Invalidate marks the bounds of the image with ID ‘id’ as dirty — for when its pixels changed, but it did not move.
+//
+// Invalidate returns false if there is no image with ID ‘id’.
+func (receiver *DamageTracker) Invalidate(id int) bool {
+	bounds, found := receiver.layers[id]
+	if !found {
+		return false
+	}
+
+	receiver.InvalidateRect(bounds)
+
+	return true
+}
+
+// InvalidateRect marks ‘r’ as dirty.
+func (receiver *DamageTracker) InvalidateRect(r image.Rectangle) {
+	if r.Empty() {
+		return
+	}
+
+	for _, dirty := range receiver.dirty {
+		if r.In(dirty) {
+			return
+		}
+	}
+
+	receiver.dirty = append(receiver.dirty, r)
+}
+
+// Dirty returns the dirty rectangles (merged by the merge heuristic), without clearing them.
+//
+// The returned rectangles are disjoint, so no pixel is repainted twice.
+func (receiver *DamageTracker) Dirty() []image.Rectangle {
+	receiver.dirty = mergeRectangles(receiver.dirty, receiver.merge())
+
+	return disjointRectangles(receiver.dirty)
+}
+
+// Flush returns the dirty rectangles (merged by the merge heuristic), and clears them — for calling once per frame.
+//
+// The returned rectangles are disjoint, so no pixel is repainted twice.
+func (receiver *DamageTracker) Flush() []image.Rectangle {
+	dirty := mergeRectangles(receiver.dirty, receiver.merge())
+	receiver.dirty = nil
+
+	return disjointRectangles(dirty)
+}
+
+func (receiver *DamageTracker) merge() func(a, b image.Rectangle) bool {
+	if nil == receiver.Merge {
+		return DefaultDamageMerge
+	}
+
+	return receiver.Merge
+}
+
+// mergeRectangles repeatedly replaces pairs of rectangles with their bounding box, while ‘merge’ says to,
+// and drops rectangles that are inside of another.
+func mergeRectangles(rects []image.Rectangle, merge func(a, b image.Rectangle) bool) []image.Rectangle {
+	rects = append([]image.Rectangle(nil), rects...)
+
+	for merged := true; merged; {
+		merged = false
+
+		for i:=0; i<len(rects); i++ {
+			for j:=i+1; j<len(rects); j++ {
+				a, b := rects[i], rects[j]
+
+				if !b.In(a) && !a.In(b) && !merge(a, b) {
+					continue
+				}
+
+				rects[i] = a.Union(b)
+				rects = append(rects[:j], rects[j+1:]...)
+				merged = true
+				j = i
+			}
+		}
+	}
+
+	return rects
+}
+
+// disjointRectangles cuts away from each rectangle the parts that are in the rectangles before it.
+func disjointRectangles(rects []image.Rectangle) []image.Rectangle {
+	var disjoint []image.Rectangle
+	for i, r := range rects {
+		disjoint = append(disjoint, subtractRects([]image.Rectangle{r}, rects[:i])...)
+	}
+
+	return disjoint
+}
+
+func area(r image.Rectangle) int {
+	if r.Empty() {
+		return 0
+	}
+
+	return r.Dx() * r.Dy()
+}
@@ -0,0 +1,71 @@
+package imagerelocate_test
+
+import (
+	"github.com/reiver/go-imagerelocate"
+
+	"image"
+	"reflect"
+
+	"testing"
+)
+
+func TestDamageTracker(t *testing.T) {
+
+	sprite := image.NewRGBA(image.Rect(0,0, 10,10))
+
+	var tracker imagerelocate.DamageTracker
+	tracker.Merge = imagerelocate.MergeIfWasteAtMost(0)
+
+	id := tracker.Add(imagerelocate.Wrap(0,0, sprite))
+	background := tracker.Add(imagerelocate.Wrap(100,100, sprite))
+
+	if expected, actual := []image.Rectangle{image.Rect(0,0, 10,10), image.Rect(100,100, 110,110)}, tracker.Flush(); !reflect.DeepEqual(expected, actual) {
+		t.Errorf("The actual dirty rectangles after adding are not what was expected.")
+		t.Logf("EXPECTED: %#v", expected)
+		t.Logf("ACTUAL:   %#v", actual)
+	}
+
+	if expected, actual := []image.Rectangle(nil), tracker.Flush(); !reflect.DeepEqual(expected, actual) {
+		t.Errorf("The actual dirty rectangles after flushing are not what was expected.")
+		t.Logf("EXPECTED: %#v", expected)
+		t.Logf("ACTUAL:   %#v", actual)
+	}
+
+	// Moving right by 5 — the old and new bounds merge, without any waste, into one rectangle.
+	tracker.Move(id, imagerelocate.Wrap(5,0, sprite))
+
+	if expected, actual := []image.Rectangle{image.Rect(0,0, 15,10)}, tracker.Flush(); !reflect.DeepEqual(expected, actual) {
+		t.Errorf("The actual dirty rectangles after moving are not what was expected.")
+		t.Logf("EXPECTED: %#v", expected)
+		t.Logf("ACTUAL:   %#v", actual)
+	}
+
+	// Moving diagonally — merging would waste pixels, so the old bounds are kept, and the new bounds are cut
+	// into the 2 rectangles that are not already in the old bounds (so nothing is repainted twice).
+	tracker.Move(id, imagerelocate.Wrap(10,5, sprite))
+
+	if expected, actual := []image.Rectangle{image.Rect(5,0, 15,10), image.Rect(10,10, 20,15), image.Rect(15,5, 20,10)}, tracker.Flush(); !reflect.DeepEqual(expected, actual) {
+		t.Errorf("The actual dirty rectangles after moving diagonally are not what was expected.")
+		t.Logf("EXPECTED: %#v", expected)
+		t.Logf("ACTUAL:   %#v", actual)
+	}
+
+	// With a more generous heuristic, they merge.
+	tracker.Merge = imagerelocate.MergeIfWasteAtMost(50)
+	tracker.Move(id, imagerelocate.Wrap(15,10, sprite))
+
+	if expected, actual := []image.Rectangle{image.Rect(10,5, 25,20)}, tracker.Flush(); !reflect.DeepEqual(expected, actual) {
+		t.Errorf("The actual dirty rectangles after moving diagonally (with merging) are not what was expected.")
+		t.Logf("EXPECTED: %#v", expected)
+		t.Logf("ACTUAL:   %#v", actual)
+	}
+
+	tracker.Invalidate(background)
+	tracker.Remove(id)
+
+	if expected, actual := []image.Rectangle{image.Rect(100,100, 110,110), image.Rect(15,10, 25,20)}, tracker.Flush(); !reflect.DeepEqual(expected, actual) {
+		t.Errorf("The actual dirty rectangles after invalidating and removing are not what was expected.")
+		t.Logf("EXPECTED: %#v", expected)
+		t.Logf("ACTUAL:   %#v", actual)
+	}
+}
@@ -0,0 +1,19 @@
+package imagerelocate
+
+import (
+	"image"
+	"image/draw"
+)
+
+// Draw is just like Go's built-in draw.Draw, except that it sees through relocated wrappers
+// (such as those returned from Wrap) around ‘src’.
+//
+// Calling draw.Draw with a relocated image makes draw.Draw fall back to its (slow) generic per-pixel loop,
+// because the relocated image hides the concrete type of the image it wraps.
+// Draw instead adjusts ‘sp’ by the offset, and calls draw.Draw with the original (concrete) image,
+// so that the fast paths in draw.Draw apply.
+func Draw(dst draw.Image, r image.Rectangle, src image.Image, sp image.Point, op draw.Op) {
+	src, offset := unwrap(src)
+
+	draw.Draw(dst, r, src, sp.Sub(offset), op)
+}
@@ -0,0 +1,78 @@
+package imagerelocate_test
+
+import (
+	"github.com/reiver/go-imagerelocate"
+
+	"image"
+	"image/draw"
+
+	"testing"
+)
+
+func TestDraw(t *testing.T) {
+
+	src := image.NewRGBA(image.Rect(0,0, 16,16))
+	for i := range src.Pix {
+		src.Pix[i] = uint8(i*7)
+	}
+
+	tests := []struct{
+		Image image.Image
+		Rectangle image.Rectangle
+		Op draw.Op
+	}{
+		{
+			Image: imagerelocate.Wrap(3,5, src),
+			Rectangle: image.Rect(0,0, 32,32),
+			Op: draw.Src,
+		},
+		{
+			Image: imagerelocate.Wrap(-4,2, imagerelocate.Wrap(10,-1, src)),
+			Rectangle: image.Rect(2,2, 20,12),
+			Op: draw.Over,
+		},
+		{
+			Image: src,
+			Rectangle: image.Rect(0,0, 32,32),
+			Op: draw.Over,
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		expected := image.NewRGBA(image.Rect(0,0, 32,32))
+		draw.Draw(expected, test.Rectangle, test.Image, test.Rectangle.Min, test.Op)
+
+		actual := image.NewRGBA(image.Rect(0,0, 32,32))
+		imagerelocate.Draw(actual, test.Rectangle, test.Image, test.Rectangle.Min, test.Op)
+
+		if string(expected.Pix) != string(actual.Pix) {
+			t.Errorf("For test #%d, the actual pixels are not what was expected.", testNumber)
+			continue
+		}
+	}
+}
+
+func BenchmarkDraw_naive(b *testing.B) {
+	src := image.NewRGBA(image.Rect(0,0, 256,256))
+	dst := image.NewRGBA(image.Rect(0,0, 512,512))
+
+	img := imagerelocate.Wrap(100,100, src)
+
+	b.ResetTimer()
+	for i:=0; i<b.N; i++ {
+		draw.Draw(dst, dst.Rect, img, image.Point{}, draw.Over)
+	}
+}
+
+func BenchmarkDraw(b *testing.B) {
+	src := image.NewRGBA(image.Rect(0,0, 256,256))
+	dst := image.NewRGBA(image.Rect(0,0, 512,512))
+
+	img := imagerelocate.Wrap(100,100, src)
+
+	b.ResetTimer()
+	for i:=0; i<b.N; i++ {
+		imagerelocate.Draw(dst, dst.Rect, img, image.Point{}, draw.Over)
+	}
+}
@@ -0,0 +1,55 @@
+package imagerelocate
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+// DrawMask is like Go's built-in draw.DrawMask, except that rather than having to
+// pass ‘sp’ and ‘mp’, ‘src’ and ‘mask’ are expected to already be placed (for example with Wrap)
+// in the same coordinate space as ‘dst’.
+//
+// So, for example:
+//
+//	imagerelocate.DrawMask(dst, dst.Bounds(), imagerelocate.Wrap(x,y, sprite), imagerelocate.Wrap(x,y, spriteMask), draw.Over)
+//
+// Relocated wrappers around ‘src’ and ‘mask’ are seen through, and ‘sp’ and ‘mp’ worked out
+// from their offsets, so that draw.DrawMask gets the original (concrete) images, and can use its fast paths.
+//
+// A nil ‘mask’ is treated as fully opaque.
+func DrawMask(dst draw.Image, r image.Rectangle, src image.Image, mask image.Image, op draw.Op) {
+	src, srcOffset := unwrap(src)
+	sp := r.Min.Sub(srcOffset)
+
+	if nil == mask {
+		draw.DrawMask(dst, r, src, sp, nil, image.Point{}, op)
+		return
+	}
+
+	mask, maskOffset := unwrap(mask)
+	mp := r.Min.Sub(maskOffset)
+
+	draw.DrawMask(dst, r, src, sp, mask, mp, op)
+}
+
+// AlphaMask returns an *image.Alpha made from the alpha channel of ‘img’, with the same bounds as ‘img’.
+//
+// Since it has the same bounds, a mask made from a relocated image is relocated the same way, and can be passed to DrawMask as is.
+//
+// ‘img’ must be bounded (see IsUnbounded).
+func AlphaMask(img image.Image) *image.Alpha {
+	bounds := img.Bounds()
+
+	mask := image.NewAlpha(bounds)
+
+	for y:=bounds.Min.Y; y<bounds.Max.Y; y++ {
+		for x:=bounds.Min.X; x<bounds.Max.X; x++ {
+			_,_,_,a := img.At(x,y).RGBA()
+
+			mask.SetAlpha(x,y, color.Alpha{A:uint8(a>>8)})
+		}
+	}
+
+	return mask
+}
@@ -0,0 +1,73 @@
+package imagerelocate_test
+
+import (
+	"github.com/reiver/go-imagerelocate"
+
+	"image"
+	"image/color"
+	"image/draw"
+
+	"testing"
+)
+
+func TestDrawMask(t *testing.T) {
+
+	red := color.RGBA{R:255, A:255}
+
+	src := image.NewUniform(red)
+
+	// A 3×3 mask which only lets the center pixel through.
+	shape := image.NewRGBA(image.Rect(0,0, 3,3))
+	shape.Set(1,1, color.White)
+
+	tests := []struct{
+		X int
+		Y int
+		WrapMask bool
+	}{
+		{
+			X: 0,
+			Y: 0,
+		},
+		{
+			X: 4,
+			Y: 2,
+		},
+		{
+			X: -1,
+			Y: 5,
+		},
+		{
+			X: 4,
+			Y: 2,
+			WrapMask: true,
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		dst := image.NewRGBA(image.Rect(0,0, 8,8))
+
+		var mask image.Image = imagerelocate.AlphaMask(imagerelocate.Wrap(test.X, test.Y, shape))
+		if test.WrapMask {
+			mask = imagerelocate.Wrap(test.X, test.Y, imagerelocate.AlphaMask(shape))
+		}
+
+		imagerelocate.DrawMask(dst, dst.Bounds(), src, mask, draw.Over)
+
+		for y:=0; y<8; y++ {
+			for x:=0; x<8; x++ {
+				var expected color.RGBA
+				if x == test.X+1 && y == test.Y+1 {
+					expected = red
+				}
+
+				if actual := dst.RGBAAt(x,y); expected != actual {
+					t.Errorf("For test #%d, the actual color at (%d,%d) is not what was expected.", testNumber, x,y)
+					t.Logf("EXPECTED: %#v", expected)
+					t.Logf("ACTUAL:   %#v", actual)
+				}
+			}
+		}
+	}
+}
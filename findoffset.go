@@ -0,0 +1,251 @@
+package imagerelocate
+
+import (
+	"image"
+	"math"
+)
+
+// findOffsetBruteForceMaxShift is the largest ‘maxShift’ FindOffset uses brute force for.
+const findOffsetBruteForceMaxShift = 8
+
+// FindOffset finds the integer (dx,dy), with both |dx| and |dy| at most ‘maxShift’, that best aligns ‘a’ with ‘b’ —
+// i.e., that minimizes the difference between ‘b’ and Wrap(dx,dy, a) where they overlap.
+//
+// For example, if ‘b’ is a screenshot that is shifted 3 pixels to the right of screenshot ‘a’, then FindOffset returns (3,0).
+//
+// It also returns a confidence score between 0 and 1 — how much better the best offset was than the next best offset
+// (that is not right next to it). A confidence near 0 means the match is ambiguous (for example, for blank images).
+//
+// For small ‘maxShift’ FindOffsetBruteForce is used; otherwise FindOffsetPyramid is used.
+//
+// ‘a’ and ‘b’ must be bounded (see IsUnbounded).
+func FindOffset(a, b image.Image, maxShift int) (offset image.Point, confidence float64) {
+	if maxShift <= findOffsetBruteForceMaxShift {
+		return FindOffsetBruteForce(a, b, maxShift)
+	}
+
+	return FindOffsetPyramid(a, b, maxShift)
+}
+
+// FindOffsetBruteForce is like FindOffset, except it always tries every (dx,dy).
+//
+// This finds the best offset, but takes time proportional to ‘maxShift’ squared.
+func FindOffsetBruteForce(a, b image.Image, maxShift int) (offset image.Point, confidence float64) {
+	ga := newGrayGrid(a)
+	gb := newGrayGrid(b)
+
+	return searchOffset(ga, gb, image.Point{}, maxShift, maxShift)
+}
+
+// FindOffsetPyramid is like FindOffset, except it always uses an image pyramid.
+//
+// Both images are repeatedly halved in size, the offset is found by brute force at the smallest size,
+// and then refined at each larger size.
+// This is much faster than FindOffsetBruteForce for large ‘maxShift’, but can (rarely) miss the best offset
+// for images with a lot of fine repeating detail.
+func FindOffsetPyramid(a, b image.Image, maxShift int) (offset image.Point, confidence float64) {
+	pyramidA := []grayGrid{newGrayGrid(a)}
+	pyramidB := []grayGrid{newGrayGrid(b)}
+
+	// The largest shift allowed at each level; the offset found must stay within it.
+	limits := []int{maxShift}
+
+	shift := maxShift
+	for findOffsetBruteForceMaxShift < shift {
+		smallerA := pyramidA[len(pyramidA)-1].halve()
+		smallerB := pyramidB[len(pyramidB)-1].halve()
+
+		if smallerA.rect.Dx() < 8 || smallerA.rect.Dy() < 8 || smallerB.rect.Dx() < 8 || smallerB.rect.Dy() < 8 {
+			break
+		}
+
+		pyramidA = append(pyramidA, smallerA)
+		pyramidB = append(pyramidB, smallerB)
+		shift = (shift+1) / 2
+		limits = append(limits, shift)
+	}
+
+	top := len(pyramidA)-1
+
+	offset, confidence = searchOffset(pyramidA[top], pyramidB[top], image.Point{}, shift, limits[top])
+
+	for level:=top-1; 0<=level; level-- {
+		offset, confidence = searchOffset(pyramidA[level], pyramidB[level], offset.Mul(2), 2, limits[level])
+	}
+
+	return offset, confidence
+}
+
+// searchOffset tries every offset within ‘radius’ of ‘center’, and returns the one with the lowest cost, along with a confidence score.
+//
+// Only offsets with both |dx| and |dy| at most ‘limit’ are tried.
+func searchOffset(a, b grayGrid, center image.Point, radius int, limit int) (image.Point, float64) {
+	limit = max(0, limit)
+	center = image.Point{
+		X: max(-limit, min(center.X, limit)),
+		Y: max(-limit, min(center.Y, limit)),
+	}
+
+	type candidate struct {
+		offset image.Point
+		cost   float64
+	}
+
+	var candidates []candidate
+
+	// Tiny overlaps can match by accident, so first only offsets with a reasonable overlap are considered.
+	minOverlap := min(a.rect.Dx()*a.rect.Dy(), b.rect.Dx()*b.rect.Dy()) / 4
+
+	for _, required := range []int{minOverlap, 1} {
+		for dy:=max(-limit, center.Y-radius); dy<=min(center.Y+radius, limit); dy++ {
+			for dx:=max(-limit, center.X-radius); dx<=min(center.X+radius, limit); dx++ {
+				offset := image.Point{dx,dy}
+
+				cost, overlap := differenceCost(a, b, offset)
+				if overlap < required {
+					continue
+				}
+
+				candidates = append(candidates, candidate{offset:offset, cost:cost})
+			}
+		}
+
+		if 0 < len(candidates) {
+			break
+		}
+	}
+
+	if len(candidates) <= 0 {
+		return center, 0
+	}
+
+	// On a tie, the offset closest to ‘center’ wins — so that, for example, blank images do not drift to a corner.
+	best := candidates[0]
+	for _, c := range candidates[1:] {
+		if c.cost < best.cost || (c.cost == best.cost && manhattan(c.offset.Sub(center)) < manhattan(best.offset.Sub(center))) {
+			best = c
+		}
+	}
+
+	secondBest := math.Inf(1)
+	for _, c := range candidates {
+		d := c.offset.Sub(best.offset)
+		if -1 <= d.X && d.X <= 1 && -1 <= d.Y && d.Y <= 1 {
+			continue
+		}
+		if c.cost < secondBest {
+			secondBest = c.cost
+		}
+	}
+
+	var confidence float64
+	switch {
+	case math.IsInf(secondBest, 1):
+		confidence = 1
+	case 0 < secondBest:
+		confidence = (secondBest - best.cost) / secondBest
+	}
+
+	return best.offset, confidence
+}
+
+// manhattan returns |‘p.X’| + |‘p.Y’|.
+func manhattan(p image.Point) int {
+	return max(p.X, -p.X) + max(p.Y, -p.Y)
+}
+
+// differenceCost returns the mean squared difference between ‘b’ and ‘a’ relocated by ‘offset’, where they overlap,
+// and how many pixels they overlap by.
+func differenceCost(a, b grayGrid, offset image.Point) (float64, int) {
+	overlap := b.rect.Intersect(a.rect.Add(offset))
+	if overlap.Empty() {
+		return 0, 0
+	}
+
+	var sum float64
+	for y:=overlap.Min.Y; y<overlap.Max.Y; y++ {
+		rowA := a.row(y-offset.Y)
+		rowB := b.row(y)
+
+		for x:=overlap.Min.X; x<overlap.Max.X; x++ {
+			d := rowB[x-b.rect.Min.X] - rowA[x-offset.X-a.rect.Min.X]
+			sum += d*d
+		}
+	}
+
+	n := overlap.Dx()*overlap.Dy()
+
+	return sum / float64(n), n
+}
+
+// grayGrid is the luminance (between 0 and 1) of each pixel of an image.
+type grayGrid struct {
+	rect image.Rectangle
+	v    []float64
+}
+
+func newGrayGrid(img image.Image) grayGrid {
+	bounds := img.Bounds()
+
+	grid := grayGrid{
+		rect: bounds,
+		v:    make([]float64, bounds.Dx()*bounds.Dy()),
+	}
+
+	i := 0
+	for y:=bounds.Min.Y; y<bounds.Max.Y; y++ {
+		for x:=bounds.Min.X; x<bounds.Max.X; x++ {
+			r,g,b,_ := img.At(x,y).RGBA()
+
+			grid.v[i] = (0.299*float64(r) + 0.587*float64(g) + 0.114*float64(b)) / 0xffff
+			i++
+		}
+	}
+
+	return grid
+}
+
+func (receiver grayGrid) row(y int) []float64 {
+	width := receiver.rect.Dx()
+	i := (y - receiver.rect.Min.Y) * width
+
+	return receiver.v[i:i+width]
+}
+
+// halve returns the grid at half the size (in each direction), averaging each 2×2 block.
+//
+// The coordinates are halved too, so that offsets found between halved grids are half of those between the originals.
+func (receiver grayGrid) halve() grayGrid {
+	rect := image.Rectangle{
+		Min: image.Point{floorDiv(receiver.rect.Min.X, 2), floorDiv(receiver.rect.Min.Y, 2)},
+		Max: image.Point{floorDiv(receiver.rect.Max.X, 2), floorDiv(receiver.rect.Max.Y, 2)},
+	}
+
+	halved := grayGrid{
+		rect: rect,
+		v:    make([]float64, rect.Dx()*rect.Dy()),
+	}
+
+	i := 0
+	for y:=rect.Min.Y; y<rect.Max.Y; y++ {
+		for x:=rect.Min.X; x<rect.Max.X; x++ {
+			var sum float64
+			var n int
+
+			for _, p := range [4]image.Point{{2*x,2*y}, {2*x+1,2*y}, {2*x,2*y+1}, {2*x+1,2*y+1}} {
+				if !p.In(receiver.rect) {
+					continue
+				}
+				sum += receiver.row(p.Y)[p.X-receiver.rect.Min.X]
+				n++
+			}
+
+			if 0 < n {
+				halved.v[i] = sum / float64(n)
+			}
+			i++
+		}
+	}
+
+	return halved
+}
@@ -0,0 +1,150 @@
+package imagerelocate_test
+
+import (
+	"github.com/reiver/go-imagerelocate"
+
+	"image"
+	"image/color"
+	"image/draw"
+	"math/rand"
+
+	"testing"
+)
+
+func TestFindOffset(t *testing.T) {
+
+	randomness := rand.New(rand.NewSource(1))
+
+	// A "screenshot" with some blocky random content.
+	screenshot := image.NewGray(image.Rect(0,0, 160,120))
+	for i:=0; i<40; i++ {
+		x, y := randomness.Intn(150), randomness.Intn(110)
+		w, h := 2+randomness.Intn(20), 2+randomness.Intn(20)
+		draw.Draw(screenshot, image.Rect(x,y, x+w,y+h), image.NewUniform(color.Gray{Y:uint8(randomness.Intn(256))}), image.Point{}, draw.Src)
+	}
+
+	tests := []struct{
+		DX int
+		DY int
+		MaxShift int
+	}{
+		{
+			DX: 0,
+			DY: 0,
+			MaxShift: 4,
+		},
+		{
+			DX: 3,
+			DY: -2,
+			MaxShift: 4,
+		},
+		{
+			DX: -17,
+			DY: 11,
+			MaxShift: 24,
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		// The shifted screenshot, as it would have been captured (i.e., cropped to the same screen).
+		shifted := image.NewGray(screenshot.Rect)
+		draw.Draw(shifted, shifted.Rect, imagerelocate.Wrap(test.DX, test.DY, screenshot), image.Point{}, draw.Src)
+
+		offset, confidence := imagerelocate.FindOffset(screenshot, shifted, test.MaxShift)
+
+		if expected, actual := image.Pt(test.DX, test.DY), offset; expected != actual {
+			t.Errorf("For test #%d, the actual offset is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %#v", expected)
+			t.Logf("ACTUAL:   %#v", actual)
+			t.Logf("CONFIDENCE: %f", confidence)
+			continue
+		}
+
+		if confidence < 0.5 {
+			t.Errorf("For test #%d, the actual confidence was lower than expected.", testNumber)
+			t.Logf("CONFIDENCE: %f", confidence)
+			continue
+		}
+	}
+}
+
+func TestFindOffset_maxShift(t *testing.T) {
+
+	randomness := rand.New(rand.NewSource(2))
+
+	screenshot := image.NewGray(image.Rect(0,0, 160,120))
+	for i:=0; i<40; i++ {
+		x, y := randomness.Intn(150), randomness.Intn(110)
+		w, h := 2+randomness.Intn(20), 2+randomness.Intn(20)
+		draw.Draw(screenshot, image.Rect(x,y, x+w,y+h), image.NewUniform(color.Gray{Y:uint8(randomness.Intn(256))}), image.Point{}, draw.Src)
+	}
+
+	// The true shift is larger than ‘MaxShift’, so the offset found must still be within ‘MaxShift’.
+	tests := []struct{
+		DX int
+		DY int
+		MaxShift int
+	}{
+		{
+			DX: 7,
+			DY: 0,
+			MaxShift: 4,
+		},
+		{
+			DX: 14,
+			DY: 0,
+			MaxShift: 12,
+		},
+		{
+			DX: -15,
+			DY: 13,
+			MaxShift: 12,
+		},
+		{
+			DX: 40,
+			DY: -30,
+			MaxShift: 20,
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		shifted := image.NewGray(screenshot.Rect)
+		draw.Draw(shifted, shifted.Rect, imagerelocate.Wrap(test.DX, test.DY, screenshot), image.Point{}, draw.Src)
+
+		offset, _ := imagerelocate.FindOffset(screenshot, shifted, test.MaxShift)
+
+		if offset.X < -test.MaxShift || test.MaxShift < offset.X || offset.Y < -test.MaxShift || test.MaxShift < offset.Y {
+			t.Errorf("For test #%d, the actual offset is larger than the max shift.", testNumber)
+			t.Logf("MAX-SHIFT: %d", test.MaxShift)
+			t.Logf("ACTUAL:    %#v", offset)
+			continue
+		}
+	}
+}
+
+func TestFindOffset_blank(t *testing.T) {
+
+	a := image.NewGray(image.Rect(0,0, 40,30))
+	b := image.NewGray(image.Rect(0,0, 40,30))
+
+	// Every offset matches equally well, so the smallest one should be returned, for both brute force and the pyramid.
+	for _, maxShift := range []int{5, 20} {
+
+		offset, confidence := imagerelocate.FindOffset(a, b, maxShift)
+
+		if expected, actual := (image.Point{}), offset; expected != actual {
+			t.Errorf("For max shift %d, the actual offset is not what was expected.", maxShift)
+			t.Logf("EXPECTED: %#v", expected)
+			t.Logf("ACTUAL:   %#v", actual)
+			continue
+		}
+
+		if 0 != confidence {
+			t.Errorf("For max shift %d, expected a confidence of 0 for blank images.", maxShift)
+			t.Logf("CONFIDENCE: %f", confidence)
+			continue
+		}
+	}
+}
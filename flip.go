@@ -0,0 +1,52 @@
+package imagerelocate
+
+import (
+	"image"
+	"image/color"
+)
+
+// internalFlippedImage is an image mirrored horizontally, vertically, or both, within its own bounds.
+type internalFlippedImage struct {
+	img          image.Image
+	flipX, flipY bool
+}
+
+func (receiver internalFlippedImage) At(x, y int) color.Color {
+	bounds := receiver.img.Bounds()
+
+	if receiver.flipX {
+		x = bounds.Min.X + bounds.Max.X-1 - x
+	}
+	if receiver.flipY {
+		y = bounds.Min.Y + bounds.Max.Y-1 - y
+	}
+
+	return receiver.img.At(x,y)
+}
+
+func (receiver internalFlippedImage) Bounds() image.Rectangle {
+	return receiver.img.Bounds()
+}
+
+func (receiver internalFlippedImage) ColorModel() color.Model {
+	return receiver.img.ColorModel()
+}
+
+// flip returns ‘img’ mirrored horizontally (if ‘flipX’ is true) and vertically (if ‘flipY’ is true), within its own bounds.
+func flip(img image.Image, flipX, flipY bool) image.Image {
+	if !flipX && !flipY {
+		return img
+	}
+
+	if casted, ok := img.(internalFlippedImage); ok {
+		casted.flipX = casted.flipX != flipX
+		casted.flipY = casted.flipY != flipY
+		return flip(casted.img, casted.flipX, casted.flipY)
+	}
+
+	return internalFlippedImage{
+		img:   img,
+		flipX: flipX,
+		flipY: flipY,
+	}
+}
@@ -0,0 +1,3 @@
+module github.com/reiver/go-imagerelocate
+
+go 1.23
@@ -0,0 +1,184 @@
+package imagerelocate
+
+import (
+	"image"
+	"math/bits"
+)
+
+// HitMask is a precomputed bitmask of which pixels of an image are solid (for collision detection).
+//
+// Making a HitMask is about as expensive as calling Overlaps once, but after that checking
+// two HitMasks for a collision compares 64 pixels at a time.
+//
+// Moving a HitMask (with .Moved()) is constant-time, and shares the bitmask.
+type HitMask struct {
+	rect   image.Rectangle
+	stride int // the number of words per row.
+	words  []uint64
+}
+
+// NewHitMask returns a HitMask for ‘img’, where a pixel is solid if its (8-bit) alpha is greater than ‘alphaThreshold’.
+//
+// The HitMask has the same bounds as ‘img’ — so a HitMask of a relocated image is relocated the same way.
+//
+// ‘img’ must be bounded (see IsUnbounded).
+func NewHitMask(img image.Image, alphaThreshold uint8) *HitMask {
+	bounds := img.Bounds()
+
+	src, offset := unwrap(img)
+	alphaAt := alphaFunc(src)
+
+	stride := (bounds.Dx() + 63) / 64
+
+	mask := &HitMask{
+		rect:   bounds,
+		stride: stride,
+		words:  make([]uint64, stride*bounds.Dy()),
+	}
+
+	for y:=bounds.Min.Y; y<bounds.Max.Y; y++ {
+		row := mask.row(y)
+		for x:=bounds.Min.X; x<bounds.Max.X; x++ {
+			if alphaAt(x-offset.X, y-offset.Y) <= alphaThreshold {
+				continue
+			}
+
+			i := x - bounds.Min.X
+			row[i/64] |= 1 << (i%64)
+		}
+	}
+
+	return mask
+}
+
+// Bounds returns the bounds of the HitMask.
+func (receiver *HitMask) Bounds() image.Rectangle {
+	if nil == receiver {
+		return image.Rectangle{}
+	}
+
+	return receiver.rect
+}
+
+// Hit returns whether the pixel at (x,y) is solid.
+func (receiver *HitMask) Hit(x, y int) bool {
+	if nil == receiver {
+		return false
+	}
+	if !(image.Point{x,y}).In(receiver.rect) {
+		return false
+	}
+
+	i := x - receiver.rect.Min.X
+
+	return 0 != receiver.row(y)[i/64] & (1 << (i%64))
+}
+
+// Moved returns the HitMask relocated by (‘dx’,‘dy’).
+//
+// The returned HitMask shares its bitmask with the original.
+func (receiver *HitMask) Moved(dx, dy int) *HitMask {
+	if nil == receiver {
+		return nil
+	}
+
+	moved := *receiver
+	moved.rect = receiver.rect.Add(image.Point{dx,dy})
+
+	return &moved
+}
+
+// Overlaps returns whether this HitMask and ‘other’ have a solid pixel at the same (x,y).
+func (receiver *HitMask) Overlaps(other *HitMask) bool {
+	var overlaps bool
+
+	receiver.scan(other, func(y int, xs uint64, x int) bool {
+		overlaps = true
+		return false
+	})
+
+	return overlaps
+}
+
+// OverlapRect returns the tightest rectangle containing every (x,y) where this HitMask and ‘other’ both have a solid pixel.
+//
+// If they do not overlap, then an empty rectangle is returned.
+func (receiver *HitMask) OverlapRect(other *HitMask) image.Rectangle {
+	var overlap image.Rectangle
+
+	receiver.scan(other, func(y int, xs uint64, x int) bool {
+		minX := x + bits.TrailingZeros64(xs)
+		maxX := x + 64 - bits.LeadingZeros64(xs)
+
+		overlap = overlap.Union(image.Rect(minX,y, maxX,y+1))
+		return true
+	})
+
+	return overlap
+}
+
+// scan calls ‘fn’ for each (up to) 64 pixel run, within the intersection, where both HitMasks have solid pixels.
+//
+// ‘xs’ has a bit set for each colliding pixel, with the lowest bit being the pixel at (‘x’,‘y’).
+//
+// Scanning stops when ‘fn’ returns false.
+func (receiver *HitMask) scan(other *HitMask, fn func(y int, xs uint64, x int) bool) {
+	if nil == receiver || nil == other {
+		return
+	}
+
+	intersection := receiver.rect.Intersect(other.rect)
+	if intersection.Empty() {
+		return
+	}
+
+	width := intersection.Dx()
+
+	startA := intersection.Min.X - receiver.rect.Min.X
+	startB := intersection.Min.X - other.rect.Min.X
+
+	for y:=intersection.Min.Y; y<intersection.Max.Y; y++ {
+		rowA := receiver.row(y)
+		rowB := other.row(y)
+
+		for i:=0; i<width; i+=64 {
+			xs := bitsAt(rowA, startA+i) & bitsAt(rowB, startB+i)
+
+			if remaining := width - i; remaining < 64 {
+				xs &= (1 << remaining) - 1
+			}
+
+			if 0 == xs {
+				continue
+			}
+
+			if !fn(y, xs, intersection.Min.X+i) {
+				return
+			}
+		}
+	}
+}
+
+func (receiver *HitMask) row(y int) []uint64 {
+	i := (y - receiver.rect.Min.Y) * receiver.stride
+
+	return receiver.words[i:i+receiver.stride]
+}
+
+// bitsAt returns the 64 bits of ‘row’ starting at bit ‘pos’.
+//
+// Bits past the end of ‘row’ are zero.
+func bitsAt(row []uint64, pos int) uint64 {
+	w, s := pos/64, uint(pos%64)
+
+	if len(row) <= w {
+		return 0
+	}
+
+	v := row[w] >> s
+	if 0 != s && w+1 < len(row) {
+		v |= row[w+1] << (64-s)
+	}
+
+	return v
+}
@@ -18,15 +18,7 @@ func (receiver internalImage) At(x, y int) color.Color {
 }
 
 func (receiver internalImage) Bounds() image.Rectangle {
-	bounds := receiver.img.Bounds()
-
-	bounds.Min.X += receiver.x
-	bounds.Min.Y += receiver.y
-
-	bounds.Max.X += receiver.x
-	bounds.Max.Y += receiver.y
-
-	return bounds
+	return translate(receiver.img.Bounds(), receiver.x, receiver.y)
 }
 
 func (receiver internalImage) ColorModel() color.Model {
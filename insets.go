@@ -0,0 +1,49 @@
+package imagerelocate
+
+import (
+	"image"
+)
+
+// Insets are distances from each of the four edges of a rectangle.
+type Insets struct {
+	Top    int
+	Right  int
+	Bottom int
+	Left   int
+}
+
+// UniformInsets returns Insets that are ‘n’ from every edge.
+func UniformInsets(n int) Insets {
+	return Insets{
+		Top:    n,
+		Right:  n,
+		Bottom: n,
+		Left:   n,
+	}
+}
+
+// Grow returns ‘r’ made bigger by the insets.
+func (receiver Insets) Grow(r image.Rectangle) image.Rectangle {
+	r.Min.X -= receiver.Left
+	r.Min.Y -= receiver.Top
+	r.Max.X += receiver.Right
+	r.Max.Y += receiver.Bottom
+
+	return r
+}
+
+// Shrink returns ‘r’ made smaller by the insets.
+//
+// If the insets are bigger than ‘r’, then an empty rectangle is returned.
+func (receiver Insets) Shrink(r image.Rectangle) image.Rectangle {
+	r.Min.X += receiver.Left
+	r.Min.Y += receiver.Top
+	r.Max.X -= receiver.Right
+	r.Max.Y -= receiver.Bottom
+
+	if r.Empty() {
+		return image.Rectangle{}
+	}
+
+	return r
+}
@@ -0,0 +1,199 @@
+package imagerelocate
+
+import (
+	"image"
+	"image/color"
+	"iter"
+)
+
+// Pixels returns an iterator over every pixel of ‘img’ — from top to bottom, and left to right — yielding
+// each pixel's (global) coordinates and its color.
+//
+// For example:
+//
+//	for p, c := range imagerelocate.Pixels(imagerelocate.Wrap(x,y, sprite)) {
+//		// ...
+//	}
+//
+// Relocated wrappers (such as those returned from Wrap) around ‘img’ are seen through; and if the image they wrap is
+// an *image.RGBA, *image.NRGBA, *image.RGBA64, *image.Gray, or *image.Alpha, its .Pix is read directly rather than going through .At().
+//
+// ‘img’ must be bounded (see IsUnbounded).
+func Pixels(img image.Image) iter.Seq2[image.Point, color.Color] {
+	return func(yield func(image.Point, color.Color) bool) {
+		bounds := img.Bounds()
+		src, offset := unwrap(img)
+		colorAt := colorFunc(src)
+
+		for y:=bounds.Min.Y; y<bounds.Max.Y; y++ {
+			for x:=bounds.Min.X; x<bounds.Max.X; x++ {
+				if !yield(image.Point{x,y}, colorAt(x-offset.X, y-offset.Y)) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Rows returns an iterator over every row of ‘img’, from top to bottom, yielding each row's (global) y-coordinate
+// and its colors (alpha-premultiplied, as color.RGBA64) from left to right.
+//
+// The first color in each row is of the pixel at x = img.Bounds().Min.X.
+//
+// The yielded slice is reused for the next row — so it must not be kept after the loop body finishes (copy it if needed).
+//
+// Just like Pixels, relocated wrappers are seen through, and the .Pix of stdlib concrete image types is read directly.
+//
+// ‘img’ must be bounded (see IsUnbounded).
+func Rows(img image.Image) iter.Seq2[int, []color.RGBA64] {
+	return func(yield func(int, []color.RGBA64) bool) {
+		bounds := img.Bounds()
+		src, offset := unwrap(img)
+		fill := rowFunc(src)
+
+		row := make([]color.RGBA64, bounds.Dx())
+
+		for y:=bounds.Min.Y; y<bounds.Max.Y; y++ {
+			fill(row, bounds.Min.X-offset.X, y-offset.Y)
+
+			if !yield(y, row) {
+				return
+			}
+		}
+	}
+}
+
+// colorFunc returns a func that returns the color of the pixel at (x,y) in ‘img’,
+// reading .Pix directly for the stdlib concrete image types.
+func colorFunc(img image.Image) func(x, y int) color.Color {
+	switch casted := img.(type) {
+	case *image.RGBA:
+		return func(x, y int) color.Color {
+			return casted.RGBAAt(x,y)
+		}
+	case *image.NRGBA:
+		return func(x, y int) color.Color {
+			return casted.NRGBAAt(x,y)
+		}
+	case *image.RGBA64:
+		return func(x, y int) color.Color {
+			return casted.RGBA64At(x,y)
+		}
+	case *image.Gray:
+		return func(x, y int) color.Color {
+			return casted.GrayAt(x,y)
+		}
+	case *image.Alpha:
+		return func(x, y int) color.Color {
+			return casted.AlphaAt(x,y)
+		}
+	default:
+		return img.At
+	}
+}
+
+// rowFunc returns a func that fills ‘row’ with the colors of the pixels of ‘img’ starting at (x,y) and going right,
+// reading .Pix directly for the stdlib concrete image types.
+//
+// Pixels outside of the bounds of ‘img’ are transparent.
+func rowFunc(img image.Image) func(row []color.RGBA64, x, y int) {
+	bounds := img.Bounds()
+
+	// inside returns the part of the row, starting at (x,y), that is within the bounds; clearing the rest.
+	inside := func(row []color.RGBA64, x, y int) (int, int) {
+		clear(row)
+
+		if y < bounds.Min.Y || bounds.Max.Y <= y {
+			return 0, 0
+		}
+
+		from := max(0, bounds.Min.X-x)
+		to := min(len(row), bounds.Max.X-x)
+		if to < from {
+			return 0, 0
+		}
+
+		return from, to
+	}
+
+	switch casted := img.(type) {
+	case *image.RGBA:
+		return func(row []color.RGBA64, x, y int) {
+			from, to := inside(row, x, y)
+			if from == to {
+				return
+			}
+			pix := casted.Pix[casted.PixOffset(x+from, y):]
+			for i:=from; i<to; i++ {
+				s := pix[4*(i-from):]
+				row[i] = color.RGBA64{
+					R: uint16(s[0])*0x101,
+					G: uint16(s[1])*0x101,
+					B: uint16(s[2])*0x101,
+					A: uint16(s[3])*0x101,
+				}
+			}
+		}
+	case *image.NRGBA:
+		return func(row []color.RGBA64, x, y int) {
+			from, to := inside(row, x, y)
+			if from == to {
+				return
+			}
+			pix := casted.Pix[casted.PixOffset(x+from, y):]
+			for i:=from; i<to; i++ {
+				s := pix[4*(i-from):]
+				r,g,b,a := color.NRGBA{R:s[0], G:s[1], B:s[2], A:s[3]}.RGBA()
+				row[i] = color.RGBA64{R:uint16(r), G:uint16(g), B:uint16(b), A:uint16(a)}
+			}
+		}
+	case *image.RGBA64:
+		return func(row []color.RGBA64, x, y int) {
+			from, to := inside(row, x, y)
+			if from == to {
+				return
+			}
+			pix := casted.Pix[casted.PixOffset(x+from, y):]
+			for i:=from; i<to; i++ {
+				s := pix[8*(i-from):]
+				row[i] = color.RGBA64{
+					R: uint16(s[0])<<8 | uint16(s[1]),
+					G: uint16(s[2])<<8 | uint16(s[3]),
+					B: uint16(s[4])<<8 | uint16(s[5]),
+					A: uint16(s[6])<<8 | uint16(s[7]),
+				}
+			}
+		}
+	case *image.Gray:
+		return func(row []color.RGBA64, x, y int) {
+			from, to := inside(row, x, y)
+			if from == to {
+				return
+			}
+			pix := casted.Pix[casted.PixOffset(x+from, y):]
+			for i:=from; i<to; i++ {
+				v := uint16(pix[i-from])*0x101
+				row[i] = color.RGBA64{R:v, G:v, B:v, A:0xffff}
+			}
+		}
+	case *image.Alpha:
+		return func(row []color.RGBA64, x, y int) {
+			from, to := inside(row, x, y)
+			if from == to {
+				return
+			}
+			pix := casted.Pix[casted.PixOffset(x+from, y):]
+			for i:=from; i<to; i++ {
+				v := uint16(pix[i-from])*0x101
+				row[i] = color.RGBA64{R:v, G:v, B:v, A:v}
+			}
+		}
+	default:
+		return func(row []color.RGBA64, x, y int) {
+			for i := range row {
+				r,g,b,a := img.At(x+i, y).RGBA()
+				row[i] = color.RGBA64{R:uint16(r), G:uint16(g), B:uint16(b), A:uint16(a)}
+			}
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package imagerelocate_test
+
+import (
+	"github.com/reiver/go-imagerelocate"
+
+	"image"
+	"image/color"
+	"math/rand"
+
+	"testing"
+)
+
+func TestPixels_and_Rows(t *testing.T) {
+
+	randomness := rand.New(rand.NewSource(1))
+
+	bounds := image.Rect(-3,2, 14,9)
+
+	rgba := image.NewRGBA(bounds)
+	nrgba := image.NewNRGBA(bounds)
+	rgba64 := image.NewRGBA64(bounds)
+	gray := image.NewGray(bounds)
+	alpha := image.NewAlpha(bounds)
+	paletted := image.NewPaletted(bounds, color.Palette{color.Black, color.White, color.Transparent})
+
+	for _, pix := range [][]uint8{rgba.Pix, nrgba.Pix, rgba64.Pix, gray.Pix, alpha.Pix} {
+		for i := range pix {
+			pix[i] = uint8(randomness.Intn(256))
+		}
+	}
+	for i := range paletted.Pix {
+		paletted.Pix[i] = uint8(randomness.Intn(3))
+	}
+
+	// Premultiplied, so that it is a valid *image.RGBA.
+	for i:=0; i<len(rgba.Pix); i+=4 {
+		for j:=0; j<3; j++ {
+			rgba.Pix[i+j] = min(rgba.Pix[i+j], rgba.Pix[i+3])
+		}
+	}
+
+	tests := []struct{
+		Image image.Image
+	}{
+		{Image: imagerelocate.Wrap(5,-7, rgba)},
+		{Image: imagerelocate.Wrap(5,-7, nrgba)},
+		{Image: imagerelocate.Wrap(5,-7, rgba64)},
+		{Image: imagerelocate.Wrap(5,-7, gray)},
+		{Image: imagerelocate.Wrap(5,-7, alpha)},
+		{Image: imagerelocate.Wrap(5,-7, paletted)},
+		{Image: rgba},
+	}
+
+	for testNumber, test := range tests {
+
+		img := test.Image
+		bounds := img.Bounds()
+
+		var count int
+		for p, c := range imagerelocate.Pixels(img) {
+			if expected, actual := color.RGBA64Model.Convert(img.At(p.X, p.Y)), color.RGBA64Model.Convert(c); expected != actual {
+				t.Errorf("For test #%d, the actual color at (%d,%d) is not what was expected.", testNumber, p.X, p.Y)
+				t.Logf("EXPECTED: %#v", expected)
+				t.Logf("ACTUAL:   %#v", actual)
+				return
+			}
+			count++
+		}
+
+		if expected, actual := bounds.Dx()*bounds.Dy(), count; expected != actual {
+			t.Errorf("For test #%d, the actual number of pixels is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %d", expected)
+			t.Logf("ACTUAL:   %d", actual)
+			continue
+		}
+
+		expectedY := bounds.Min.Y
+		for y, row := range imagerelocate.Rows(img) {
+			if expected, actual := expectedY, y; expected != actual {
+				t.Errorf("For test #%d, the actual y is not what was expected.", testNumber)
+				t.Logf("EXPECTED: %d", expected)
+				t.Logf("ACTUAL:   %d", actual)
+				return
+			}
+			expectedY++
+
+			for i, c := range row {
+				x := bounds.Min.X + i
+
+				if expected, actual := color.RGBA64Model.Convert(img.At(x,y)), color.Color(c); expected != actual {
+					t.Errorf("For test #%d, the actual row color at (%d,%d) is not what was expected.", testNumber, x,y)
+					t.Logf("EXPECTED: %#v", expected)
+					t.Logf("ACTUAL:   %#v", actual)
+					return
+				}
+			}
+		}
+
+		if expected, actual := bounds.Max.Y, expectedY; expected != actual {
+			t.Errorf("For test #%d, the actual number of rows is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %d", expected)
+			t.Logf("ACTUAL:   %d", actual)
+			continue
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package imagerelocate
+
+import (
+	"bytes"
+	"image"
+	"image/draw"
+)
+
+// Locate finds everywhere ‘needle’ appears inside of ‘haystack’ (for example, an icon inside of a screenshot).
+//
+// Each match is returned as ‘needle’ relocated to where it was found — i.e., in the coordinate space of ‘haystack’.
+// So the .Bounds() of each match is where in ‘haystack’ it was found.
+// Matches are returned in order, from top to bottom, and left to right; and they may overlap.
+//
+// If ‘tolerance’ is 0, then only exact matches are found, and each possible position is given up on as soon as one pixel does not match.
+// Otherwise each (8-bit, alpha-premultiplied) red, green, blue, and alpha value may differ by up to ‘tolerance’.
+//
+// ‘haystack’ and ‘needle’ must be bounded (see IsUnbounded).
+func Locate(haystack, needle image.Image, tolerance uint8) []image.Image {
+	needleBounds := needle.Bounds()
+	if needleBounds.Empty() {
+		return nil
+	}
+
+	h := toRGBA(haystack)
+	n := toRGBA(needle)
+
+	width  := n.Rect.Dx()
+	height := n.Rect.Dy()
+	rowLength := 4*width
+
+	matches := func(x, y int) bool {
+		for row:=0; row<height; row++ {
+			i := h.PixOffset(x, y+row)
+			j := n.PixOffset(n.Rect.Min.X, n.Rect.Min.Y+row)
+
+			hRow := h.Pix[i:i+rowLength]
+			nRow := n.Pix[j:j+rowLength]
+
+			if 0 == tolerance {
+				if !bytes.Equal(hRow, nRow) {
+					return false
+				}
+				continue
+			}
+
+			for k := range nRow {
+				if tolerance < absDiff(hRow[k], nRow[k]) {
+					return false
+				}
+			}
+		}
+
+		return true
+	}
+
+	var found []image.Image
+
+	for y:=h.Rect.Min.Y; y<=h.Rect.Max.Y-height; y++ {
+		for x:=h.Rect.Min.X; x<=h.Rect.Max.X-width; x++ {
+			if !matches(x,y) {
+				continue
+			}
+
+			found = append(found, Wrap(x-needleBounds.Min.X, y-needleBounds.Min.Y, needle))
+		}
+	}
+
+	return found
+}
+
+// toRGBA returns ‘img’ as an *image.RGBA, with the same bounds, converting it if it is not one already.
+func toRGBA(img image.Image) *image.RGBA {
+	if casted, ok := img.(*image.RGBA); ok {
+		return casted
+	}
+
+	bounds := img.Bounds()
+
+	rgba := image.NewRGBA(bounds)
+	Draw(rgba, bounds, img, bounds.Min, draw.Src)
+
+	return rgba
+}
+
+func absDiff(a, b uint8) uint8 {
+	if a < b {
+		return b - a
+	}
+
+	return a - b
+}
@@ -0,0 +1,91 @@
+package imagerelocate_test
+
+import (
+	"github.com/reiver/go-imagerelocate"
+
+	"image"
+	"image/color"
+	"image/draw"
+
+	"testing"
+)
+
+func TestLocate(t *testing.T) {
+
+	// A 3×2 icon.
+	icon := image.NewRGBA(image.Rect(0,0, 3,2))
+	icon.Set(0,0, color.RGBA{R:255, A:255})
+	icon.Set(1,0, color.RGBA{G:255, A:255})
+	icon.Set(2,0, color.RGBA{B:255, A:255})
+	icon.Set(0,1, color.RGBA{R:255, G:255, A:255})
+	icon.Set(1,1, color.RGBA{A:255})
+	icon.Set(2,1, color.RGBA{R:255, B:255, A:255})
+
+	// A slightly different version of the icon.
+	similar := image.NewRGBA(icon.Rect)
+	draw.Draw(similar, similar.Rect, icon, image.Point{}, draw.Src)
+	similar.Set(1,1, color.RGBA{R:5, G:5, B:5, A:255})
+
+	screenshot := image.NewRGBA(image.Rect(-10,-10, 30,30))
+	draw.Draw(screenshot, screenshot.Rect, image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(screenshot, screenshot.Rect, imagerelocate.Wrap(-4,7, icon), screenshot.Rect.Min, draw.Over)
+	draw.Draw(screenshot, screenshot.Rect, imagerelocate.Wrap(20,20, similar), screenshot.Rect.Min, draw.Over)
+
+	tests := []struct{
+		Needle image.Image
+		Tolerance uint8
+		Expected []image.Rectangle
+	}{
+		{
+			Needle: icon,
+			Tolerance: 0,
+			Expected: []image.Rectangle{
+				image.Rect(-4,7, -1,9),
+			},
+		},
+		{
+			Needle: icon,
+			Tolerance: 5,
+			Expected: []image.Rectangle{
+				image.Rect(-4,7, -1,9),
+				image.Rect(20,20, 23,22),
+			},
+		},
+		{
+			// The needle's own location should not matter.
+			Needle: imagerelocate.Wrap(100,100, icon),
+			Tolerance: 0,
+			Expected: []image.Rectangle{
+				image.Rect(-4,7, -1,9),
+			},
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		found := imagerelocate.Locate(screenshot, test.Needle, test.Tolerance)
+
+		if expected, actual := len(test.Expected), len(found); expected != actual {
+			t.Errorf("For test #%d, the actual number of matches is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %d", expected)
+			t.Logf("ACTUAL:   %d", actual)
+			continue
+		}
+
+		for i, match := range found {
+			if expected, actual := test.Expected[i], match.Bounds(); expected != actual {
+				t.Errorf("For test #%d, the actual bounds of match #%d are not what was expected.", testNumber, i)
+				t.Logf("EXPECTED: %#v", expected)
+				t.Logf("ACTUAL:   %#v", actual)
+				continue
+			}
+
+			if expected, actual := screenshot.At(test.Expected[i].Min.X, test.Expected[i].Min.Y), match.At(test.Expected[i].Min.X, test.Expected[i].Min.Y); expected != actual {
+				t.Errorf("For test #%d, the actual color of match #%d is not what was expected.", testNumber, i)
+				t.Logf("EXPECTED: %#v", expected)
+				t.Logf("ACTUAL:   %#v", actual)
+				continue
+			}
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package imagerelocate
+
+import (
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	errBadBuffer = errors.New("imagerelocate: buffer must be an *image.RGBA, *image.NRGBA, or *image.RGBA64")
+	errBadModel  = errors.New("imagerelocate: model must be color.RGBAModel, color.NRGBAModel, or color.RGBA64Model")
+	errUnbounded = errors.New("imagerelocate: cannot materialize an unbounded image")
+)
+
+// materializeBandHeight is how many rows each unit of work (a "band") covers.
+const materializeBandHeight = 32
+
+// MaterializeOptions are the options for Materialize.
+//
+// ‘Context’, if not nil, can be used to cancel Materialize.
+//
+// ‘Workers’ is how many goroutines render (row bands) in parallel.
+// If ‘Workers’ is zero (or negative) then runtime.GOMAXPROCS(0) is used.
+//
+// ‘Buffer’, if not nil, is reused if it is big enough. It must be an *image.RGBA, *image.NRGBA, or *image.RGBA64.
+// A nil pointer of one of those types is treated the same as a nil ‘Buffer’.
+//
+// ‘Model’ picks what type is created when ‘Buffer’ is nil (or not big enough):
+// color.RGBAModel (the default) creates an *image.RGBA,
+// color.NRGBAModel creates an *image.NRGBA,
+// and color.RGBA64Model creates an *image.RGBA64.
+type MaterializeOptions struct {
+	Context context.Context
+	Workers int
+	Buffer  draw.Image
+	Model   color.Model
+}
+
+// Materialize renders ‘img’ (for example a lazy view returned from Wrap, or a stack of them) into a concrete image,
+// with the same bounds as ‘img’.
+//
+// The returned image is an *image.RGBA, *image.NRGBA, or *image.RGBA64 — see MaterializeOptions.
+//
+// If the context in ‘opts’ is canceled, then Materialize stops and returns the context's error.
+//
+// ‘img’ must be bounded (see IsUnbounded).
+func Materialize(img image.Image, opts MaterializeOptions) (draw.Image, error) {
+	if IsUnbounded(img) {
+		return nil, errUnbounded
+	}
+
+	ctx := opts.Context
+	if nil == ctx {
+		ctx = context.Background()
+	}
+
+	bounds := img.Bounds()
+
+	dst, err := materializeBuffer(bounds, opts)
+	if nil != err {
+		return nil, err
+	}
+
+	if err := ctx.Err(); nil != err {
+		return nil, err
+	}
+
+	numBands := (bounds.Dy() + materializeBandHeight - 1) / materializeBandHeight
+
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if numBands < workers {
+		workers = numBands
+	}
+
+	var next atomic.Int64
+	var waitGroup sync.WaitGroup
+
+	for worker:=0; worker<workers; worker++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+
+			for {
+				if nil != ctx.Err() {
+					return
+				}
+
+				band := int(next.Add(1)) - 1
+				if numBands <= band {
+					return
+				}
+
+				r := bounds
+				r.Min.Y = bounds.Min.Y + band*materializeBandHeight
+				r.Max.Y = r.Min.Y + materializeBandHeight
+				if bounds.Max.Y < r.Max.Y {
+					r.Max.Y = bounds.Max.Y
+				}
+
+				Draw(dst, r, img, r.Min, draw.Src)
+			}
+		}()
+	}
+
+	waitGroup.Wait()
+
+	if err := ctx.Err(); nil != err {
+		return nil, err
+	}
+
+	return dst, nil
+}
+
+// materializeBuffer returns the image Materialize renders into, reusing ‘opts.Buffer’ if it is big enough.
+func materializeBuffer(bounds image.Rectangle, opts MaterializeOptions) (draw.Image, error) {
+	width, height := bounds.Dx(), bounds.Dy()
+
+	switch buffer := opts.Buffer.(type) {
+	case nil:
+		// Nothing to reuse.
+	case *image.RGBA:
+		if nil == buffer {
+			break
+		}
+		if n := 4*width*height; n <= cap(buffer.Pix) {
+			return &image.RGBA{Pix:buffer.Pix[:n], Stride:4*width, Rect:bounds}, nil
+		}
+		return image.NewRGBA(bounds), nil
+	case *image.NRGBA:
+		if nil == buffer {
+			break
+		}
+		if n := 4*width*height; n <= cap(buffer.Pix) {
+			return &image.NRGBA{Pix:buffer.Pix[:n], Stride:4*width, Rect:bounds}, nil
+		}
+		return image.NewNRGBA(bounds), nil
+	case *image.RGBA64:
+		if nil == buffer {
+			break
+		}
+		if n := 8*width*height; n <= cap(buffer.Pix) {
+			return &image.RGBA64{Pix:buffer.Pix[:n], Stride:8*width, Rect:bounds}, nil
+		}
+		return image.NewRGBA64(bounds), nil
+	default:
+		return nil, errBadBuffer
+	}
+
+	switch opts.Model {
+	case nil, color.RGBAModel:
+		return image.NewRGBA(bounds), nil
+	case color.NRGBAModel:
+		return image.NewNRGBA(bounds), nil
+	case color.RGBA64Model:
+		return image.NewRGBA64(bounds), nil
+	default:
+		return nil, errBadModel
+	}
+}
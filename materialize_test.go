@@ -0,0 +1,119 @@
+package imagerelocate_test
+
+import (
+	"github.com/reiver/go-imagerelocate"
+
+	"context"
+	"image"
+	"image/color"
+	"image/draw"
+
+	"testing"
+)
+
+func TestMaterialize(t *testing.T) {
+
+	src := image.NewNRGBA(image.Rect(0,0, 37,101))
+	for i := range src.Pix {
+		src.Pix[i] = uint8(i*13)
+
+		// Opaque, so that converting between the models does not lose precision.
+		if 3 == i%4 {
+			src.Pix[i] = 255
+		}
+	}
+
+	img := imagerelocate.Wrap(-5,7, src)
+
+	expected := image.NewRGBA64(img.Bounds())
+	draw.Draw(expected, expected.Rect, img, expected.Rect.Min, draw.Src)
+
+	tests := []struct{
+		Options imagerelocate.MaterializeOptions
+	}{
+		{
+			Options: imagerelocate.MaterializeOptions{},
+		},
+		{
+			Options: imagerelocate.MaterializeOptions{
+				Workers: 1,
+				Model: color.NRGBAModel,
+			},
+		},
+		{
+			Options: imagerelocate.MaterializeOptions{
+				Workers: 3,
+				Model: color.RGBA64Model,
+			},
+		},
+		{
+			Options: imagerelocate.MaterializeOptions{
+				Workers: 16,
+				Buffer: image.NewRGBA(image.Rect(0,0, 100,100)),
+			},
+		},
+		{
+			// A nil *image.RGBA is the same as no buffer.
+			Options: imagerelocate.MaterializeOptions{
+				Buffer: (*image.RGBA)(nil),
+			},
+		},
+		{
+			Options: imagerelocate.MaterializeOptions{
+				Buffer: (*image.NRGBA)(nil),
+				Model: color.NRGBAModel,
+			},
+		},
+		{
+			Options: imagerelocate.MaterializeOptions{
+				Buffer: (*image.RGBA64)(nil),
+			},
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		actual, err := imagerelocate.Materialize(img, test.Options)
+		if nil != err {
+			t.Errorf("For test #%d, did not expect an error but actually got one.", testNumber)
+			t.Logf("ERROR: (%T) %s", err, err)
+			continue
+		}
+
+		if expected, actual := img.Bounds(), actual.Bounds(); expected != actual {
+			t.Errorf("For test #%d, the actual bounds are not what was expected.", testNumber)
+			t.Logf("EXPECTED: %#v", expected)
+			t.Logf("ACTUAL:   %#v", actual)
+			continue
+		}
+
+		bounds := img.Bounds()
+		for y:=bounds.Min.Y; y<bounds.Max.Y; y++ {
+			for x:=bounds.Min.X; x<bounds.Max.X; x++ {
+				expected := color.RGBA64Model.Convert(expected.At(x,y))
+				actual   := color.RGBA64Model.Convert(actual.At(x,y))
+
+				if expected != actual {
+					t.Errorf("For test #%d, the actual color at (%d,%d) is not what was expected.", testNumber, x,y)
+					t.Logf("EXPECTED: %#v", expected)
+					t.Logf("ACTUAL:   %#v", actual)
+					return
+				}
+			}
+		}
+	}
+}
+
+func TestMaterialize_canceled(t *testing.T) {
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := imagerelocate.Materialize(image.NewRGBA(image.Rect(0,0, 10,10)), imagerelocate.MaterializeOptions{Context:ctx})
+
+	if expected, actual := context.Canceled, err; expected != actual {
+		t.Errorf("The actual error is not what was expected.")
+		t.Logf("EXPECTED: %#v", expected)
+		t.Logf("ACTUAL:   %#v", actual)
+	}
+}
@@ -0,0 +1,165 @@
+package imagerelocate
+
+import (
+	"image"
+	"image/color"
+)
+
+// BlendMode is how a Mosaic blends where its tiles overlap.
+type BlendMode int
+
+const (
+	// BlendLastWins uses the pixel from the last (added) tile covering it.
+	BlendLastWins BlendMode = iota
+
+	// BlendAverage averages the pixels from every tile covering it.
+	BlendAverage
+
+	// BlendFeather is a weighted average of the pixels from every tile covering it,
+	// where each tile's weight fades out towards its edges — which hides the seams.
+	BlendFeather
+)
+
+// MosaicTile is one tile of a Mosaic — an image, and where it goes.
+type MosaicTile struct {
+	Image  image.Image
+	Offset image.Point
+}
+
+// Placed returns the tile's image relocated by its offset.
+func (receiver MosaicTile) Placed() image.Image {
+	return Wrap(receiver.Offset.X, receiver.Offset.Y, receiver.Image)
+}
+
+// Mosaic assembles one image from (possibly overlapping) tiles — for example, the tiles captured by a microscope on a grid.
+//
+// Each tile's offset can be known exactly, or approximately and then refined (with .Refine()).
+type Mosaic struct {
+	Tiles []MosaicTile
+	Blend BlendMode
+}
+
+// Add adds a tile, with its (possibly approximate) offset.
+func (receiver *Mosaic) Add(img image.Image, offset image.Point) {
+	receiver.Tiles = append(receiver.Tiles, MosaicTile{
+		Image:  img,
+		Offset: offset,
+	})
+}
+
+// refineMinConfidence is the confidence (see FindOffset) that a match must be above for Mosaic.Refine to use it.
+const refineMinConfidence = 0.05
+
+// Refine refines the offset of each tile (except the first, which everything else is aligned to) by matching it
+// against the tiles before it, where they overlap. (See FindOffset.)
+//
+// Each offset is moved by at most ‘maxShift’ in each direction.
+// If a tile overlaps more than one of the tiles before it, the match with the highest confidence is used.
+// A tile that does not overlap any of the tiles before it, or whose matches are all ambiguous
+// (for example, where the overlap is blank), is left as is.
+func (receiver *Mosaic) Refine(maxShift int) {
+	for i:=1; i<len(receiver.Tiles); i++ {
+		tile := receiver.Tiles[i]
+		placed := tile.Placed()
+		search := placed.Bounds().Inset(-maxShift)
+
+		var best image.Point
+		var bestConfidence float64 = refineMinConfidence
+
+		for _, before := range receiver.Tiles[:i] {
+			beforePlaced := before.Placed()
+
+			overlap := search.Intersect(beforePlaced.Bounds())
+			if overlap.Empty() {
+				continue
+			}
+
+			delta, confidence := FindOffset(placed, crop(beforePlaced, overlap), maxShift)
+			if bestConfidence < confidence {
+				best = delta
+				bestConfidence = confidence
+			}
+		}
+
+		receiver.Tiles[i].Offset = tile.Offset.Add(best)
+	}
+}
+
+// Image returns the (lazily) composited mosaic.
+//
+// The returned image is a snapshot — changes made to the Mosaic after calling .Image() do not show up in it.
+func (receiver Mosaic) Image() image.Image {
+	index := NewSpatialIndex(0)
+
+	tiles := make([]image.Image, len(receiver.Tiles))
+	for i, tile := range receiver.Tiles {
+		tiles[i] = tile.Placed()
+		index.Insert(tiles[i])
+	}
+
+	return internalMosaicImage{
+		index: index,
+		blend: receiver.Blend,
+	}
+}
+
+// internalMosaicImage is the composited image returned from Mosaic.Image().
+type internalMosaicImage struct {
+	index *SpatialIndex
+	blend BlendMode
+}
+
+func (receiver internalMosaicImage) At(x, y int) color.Color {
+	p := image.Point{x,y}
+
+	ids := receiver.index.CoveringPoint(p)
+	if len(ids) <= 0 {
+		return color.Transparent
+	}
+
+	if BlendLastWins == receiver.blend {
+		img, _ := receiver.index.Get(ids[len(ids)-1])
+		return img.At(x,y)
+	}
+
+	var r, g, b, a, total float64
+	for _, id := range ids {
+		img, _ := receiver.index.Get(id)
+
+		weight := 1.0
+		if BlendFeather == receiver.blend {
+			weight = featherWeight(img.Bounds(), p)
+		}
+
+		cr, cg, cb, ca := img.At(x,y).RGBA()
+
+		r += weight * float64(cr)
+		g += weight * float64(cg)
+		b += weight * float64(cb)
+		a += weight * float64(ca)
+		total += weight
+	}
+
+	return color.RGBA64{
+		R: uint16(r/total + 0.5),
+		G: uint16(g/total + 0.5),
+		B: uint16(b/total + 0.5),
+		A: uint16(a/total + 0.5),
+	}
+}
+
+func (receiver internalMosaicImage) Bounds() image.Rectangle {
+	return receiver.index.Image().Bounds()
+}
+
+func (receiver internalMosaicImage) ColorModel() color.Model {
+	return color.RGBA64Model
+}
+
+// featherWeight returns the weight of the pixel at ‘p’ in a tile with bounds ‘bounds’ —
+// 1 for the pixels on the edge of the tile, and growing by 1 for each pixel further in.
+func featherWeight(bounds image.Rectangle, p image.Point) float64 {
+	d := min(p.X-bounds.Min.X, bounds.Max.X-1-p.X, p.Y-bounds.Min.Y, bounds.Max.Y-1-p.Y)
+
+	return float64(d+1)
+}
@@ -0,0 +1,130 @@
+package imagerelocate_test
+
+import (
+	"github.com/reiver/go-imagerelocate"
+
+	"image"
+	"image/color"
+	"image/draw"
+	"math/rand"
+
+	"testing"
+)
+
+func TestMosaic(t *testing.T) {
+
+	randomness := rand.New(rand.NewSource(1))
+
+	// What the microscope is looking at.
+	specimen := image.NewRGBA(image.Rect(0,0, 120,120))
+	for i:=0; i<60; i++ {
+		x, y := randomness.Intn(115), randomness.Intn(115)
+		w, h := 2+randomness.Intn(15), 2+randomness.Intn(15)
+		c := color.RGBA{R:uint8(randomness.Intn(256)), G:uint8(randomness.Intn(256)), B:uint8(randomness.Intn(256)), A:255}
+		draw.Draw(specimen, image.Rect(x,y, x+w,y+h), image.NewUniform(c), image.Point{}, draw.Src)
+	}
+
+	// A 2×2 grid of overlapping 70×70 tiles.
+	//
+	// Each tile's image has its top-left corner at (0,0), like a capture would.
+	offsets := []image.Point{
+		{0,0}, {50,0},
+		{0,50}, {50,50},
+	}
+
+	tests := []struct{
+		Blend imagerelocate.BlendMode
+		Nudges []image.Point
+		RefineMaxShift int
+	}{
+		{
+			Blend: imagerelocate.BlendLastWins,
+		},
+		{
+			Blend: imagerelocate.BlendAverage,
+		},
+		{
+			Blend: imagerelocate.BlendFeather,
+		},
+		{
+			Blend: imagerelocate.BlendFeather,
+			Nudges: []image.Point{
+				{0,0}, {3,-2},
+				{-1,4}, {2,2},
+			},
+			RefineMaxShift: 5,
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		var mosaic imagerelocate.Mosaic
+		mosaic.Blend = test.Blend
+
+		for i, offset := range offsets {
+			tile := image.NewRGBA(image.Rect(0,0, 70,70))
+			draw.Draw(tile, tile.Rect, specimen, offset, draw.Src)
+
+			approximate := offset
+			if nil != test.Nudges {
+				approximate = approximate.Add(test.Nudges[i])
+			}
+
+			mosaic.Add(tile, approximate)
+		}
+
+		if 0 < test.RefineMaxShift {
+			mosaic.Refine(test.RefineMaxShift)
+		}
+
+		for i, tile := range mosaic.Tiles {
+			if expected, actual := offsets[i], tile.Offset; expected != actual {
+				t.Errorf("For test #%d, the actual offset of tile #%d is not what was expected.", testNumber, i)
+				t.Logf("EXPECTED: %#v", expected)
+				t.Logf("ACTUAL:   %#v", actual)
+			}
+		}
+
+		img := mosaic.Image()
+
+		if expected, actual := specimen.Rect, img.Bounds(); expected != actual {
+			t.Errorf("For test #%d, the actual bounds are not what was expected.", testNumber)
+			t.Logf("EXPECTED: %#v", expected)
+			t.Logf("ACTUAL:   %#v", actual)
+			continue
+		}
+
+		for y:=0; y<120; y++ {
+			for x:=0; x<120; x++ {
+				expected := color.RGBA64Model.Convert(specimen.At(x,y))
+				actual   := color.RGBA64Model.Convert(img.At(x,y))
+
+				if expected != actual {
+					t.Errorf("For test #%d, the actual color at (%d,%d) is not what was expected.", testNumber, x,y)
+					t.Logf("EXPECTED: %#v", expected)
+					t.Logf("ACTUAL:   %#v", actual)
+					return
+				}
+			}
+		}
+	}
+}
+
+func TestMosaic_Refine_blank(t *testing.T) {
+
+	white := image.NewRGBA(image.Rect(0,0, 100,100))
+	draw.Draw(white, white.Rect, image.NewUniform(color.White), image.Point{}, draw.Src)
+
+	var mosaic imagerelocate.Mosaic
+	mosaic.Add(white, image.Pt(0,0))
+	mosaic.Add(white, image.Pt(80,0))
+
+	// The overlap is blank, so there is nothing to match; and the tile should be left where it is.
+	mosaic.Refine(5)
+
+	if expected, actual := image.Pt(80,0), mosaic.Tiles[1].Offset; expected != actual {
+		t.Errorf("The actual offset is not what was expected.")
+		t.Logf("EXPECTED: %#v", expected)
+		t.Logf("ACTUAL:   %#v", actual)
+	}
+}
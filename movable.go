@@ -0,0 +1,119 @@
+package imagerelocate
+
+import (
+	"image"
+	"image/color"
+	"sync/atomic"
+)
+
+// Movable is a relocated image whose offset can be changed — for example, a sprite whose position is updated
+// by the game-logic goroutine while the render goroutine reads it.
+//
+// .MoveTo() and .MoveBy() change the offset atomically; and .At(), .Bounds(), and .Snapshot() are safe to call
+// at the same time from other goroutines.
+//
+// Calling .At() and then .Bounds() might see different offsets if the image is moved between the calls.
+// For a consistent view (for example, for a whole frame), use .Snapshot().
+//
+// The zero value is an empty image at (0, 0). Use NewMovable to create a Movable of an image.
+type Movable struct {
+	img    image.Image
+	offset atomic.Pointer[image.Point]
+}
+
+var _ image.Image = &Movable{}
+var _ Unbounded = &Movable{}
+
+// emptyImage is what the zero value of Movable is a Movable of.
+var emptyImage image.Image = &image.RGBA64{}
+
+// NewMovable returns a Movable of ‘img’, relocated to (‘x’, ‘y’).
+//
+// ‘img’ itself is not expected to change.
+func NewMovable(x,y int, img image.Image) *Movable {
+	movable := &Movable{
+		img: img,
+	}
+	movable.offset.Store(&image.Point{x,y})
+
+	return movable
+}
+
+// MoveTo relocates the image to (‘x’, ‘y’).
+func (receiver *Movable) MoveTo(x, y int) {
+	receiver.offset.Store(&image.Point{x,y})
+}
+
+// MoveBy moves the image by (‘dx’, ‘dy’) from where it is.
+func (receiver *Movable) MoveBy(dx, dy int) {
+	for {
+		old := receiver.offset.Load()
+
+		var moved image.Point
+		if nil != old {
+			moved = *old
+		}
+		moved = moved.Add(image.Point{dx,dy})
+		if receiver.offset.CompareAndSwap(old, &moved) {
+			return
+		}
+	}
+}
+
+// Offset returns where the image is currently relocated to.
+func (receiver *Movable) Offset() image.Point {
+	offset := receiver.offset.Load()
+	if nil == offset {
+		return image.Point{}
+	}
+
+	return *offset
+}
+
+// Unbounded returns whether the image being moved is unbounded — see IsUnbounded.
+func (receiver *Movable) Unbounded() bool {
+	return IsUnbounded(receiver.source())
+}
+
+// source returns the image being moved.
+func (receiver *Movable) source() image.Image {
+	if nil == receiver.img {
+		return emptyImage
+	}
+
+	return receiver.img
+}
+
+// Snapshot returns an (immutable) image relocated to where the image is right now — see Wrap.
+//
+// Its bounds and pixels are consistent with each other, no matter how the Movable is moved afterwards.
+func (receiver *Movable) Snapshot() image.Image {
+	offset := receiver.Offset()
+
+	return Wrap(offset.X, offset.Y, receiver.source())
+}
+
+func (receiver *Movable) At(x, y int) color.Color {
+	offset := receiver.Offset()
+
+	return receiver.source().At(x-offset.X, y-offset.Y)
+}
+
+func (receiver *Movable) Bounds() image.Rectangle {
+	source := receiver.source()
+	if IsUnbounded(source) {
+		return source.Bounds()
+	}
+
+	offset := receiver.Offset()
+
+	return translate(source.Bounds(), offset.X, offset.Y)
+}
+
+func (receiver *Movable) ColorModel() color.Model {
+	return receiver.source().ColorModel()
+}
+
+func (receiver *Movable) relocatedSource() (image.Image, image.Point) {
+	return receiver.source(), receiver.Offset()
+}
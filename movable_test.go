@@ -0,0 +1,137 @@
+package imagerelocate_test
+
+import (
+	"github.com/reiver/go-imagerelocate"
+
+	"image"
+	"image/color"
+	"sync"
+
+	"testing"
+)
+
+func TestMovable(t *testing.T) {
+
+	sprite := image.NewRGBA(image.Rect(0,0, 2,2))
+	sprite.Set(0,0, color.RGBA{R:255, A:255})
+
+	movable := imagerelocate.NewMovable(10,20, sprite)
+
+	if expected, actual := image.Rect(10,20, 12,22), movable.Bounds(); expected != actual {
+		t.Errorf("The actual bounds are not what was expected.")
+		t.Logf("EXPECTED: %#v", expected)
+		t.Logf("ACTUAL:   %#v", actual)
+	}
+
+	snapshot := movable.Snapshot()
+
+	movable.MoveBy(5,-5)
+
+	if expected, actual := image.Pt(15,15), movable.Offset(); expected != actual {
+		t.Errorf("The actual offset is not what was expected.")
+		t.Logf("EXPECTED: %#v", expected)
+		t.Logf("ACTUAL:   %#v", actual)
+	}
+
+	if expected, actual := sprite.At(0,0), movable.At(15,15); expected != actual {
+		t.Errorf("The actual color is not what was expected.")
+		t.Logf("EXPECTED: %#v", expected)
+		t.Logf("ACTUAL:   %#v", actual)
+	}
+
+	// The snapshot should not have moved.
+	if expected, actual := image.Rect(10,20, 12,22), snapshot.Bounds(); expected != actual {
+		t.Errorf("The actual snapshot bounds are not what was expected.")
+		t.Logf("EXPECTED: %#v", expected)
+		t.Logf("ACTUAL:   %#v", actual)
+	}
+}
+
+func TestMovable_concurrent(t *testing.T) {
+
+	sprite := image.NewRGBA(image.Rect(0,0, 2,2))
+
+	movable := imagerelocate.NewMovable(0,0, sprite)
+
+	const moves = 1000
+
+	var waitGroup sync.WaitGroup
+
+	for i:=0; i<4; i++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			for j:=0; j<moves; j++ {
+				movable.MoveBy(1,2)
+			}
+		}()
+	}
+
+	waitGroup.Add(1)
+	go func() {
+		defer waitGroup.Done()
+		for j:=0; j<moves; j++ {
+			snapshot := movable.Snapshot()
+			bounds := snapshot.Bounds()
+
+			if 2*bounds.Min.X != bounds.Min.Y {
+				t.Errorf("The snapshot bounds are not consistent.")
+				t.Logf("BOUNDS: %#v", bounds)
+				return
+			}
+		}
+	}()
+
+	waitGroup.Wait()
+
+	if expected, actual := image.Pt(4*moves, 8*moves), movable.Offset(); expected != actual {
+		t.Errorf("The actual offset is not what was expected.")
+		t.Logf("EXPECTED: %#v", expected)
+		t.Logf("ACTUAL:   %#v", actual)
+	}
+}
+
+func TestMovable_unbounded(t *testing.T) {
+
+	movable := imagerelocate.NewMovable(3,4, image.NewUniform(color.White))
+
+	if !imagerelocate.IsUnbounded(movable) {
+		t.Errorf("Expected a Movable of an unbounded image to be unbounded.")
+	}
+
+	if _, err := imagerelocate.Materialize(movable, imagerelocate.MaterializeOptions{}); nil == err {
+		t.Errorf("Expected an error when materializing an unbounded Movable.")
+	}
+
+	if imagerelocate.IsUnbounded(imagerelocate.NewMovable(3,4, image.NewRGBA(image.Rect(0,0, 2,2)))) {
+		t.Errorf("Did not expect a Movable of a bounded image to be unbounded.")
+	}
+}
+
+func TestMovable_zero(t *testing.T) {
+
+	var movable imagerelocate.Movable
+
+	if expected, actual := (image.Point{}), movable.Offset(); expected != actual {
+		t.Errorf("The actual offset is not what was expected.")
+		t.Logf("EXPECTED: %#v", expected)
+		t.Logf("ACTUAL:   %#v", actual)
+	}
+
+	if !movable.Bounds().Empty() {
+		t.Errorf("Expected the zero value to be empty.")
+		t.Logf("BOUNDS: %#v", movable.Bounds())
+	}
+
+	if _, _, _, a := movable.At(0,0).RGBA(); 0 != a {
+		t.Errorf("Expected the zero value to be transparent.")
+	}
+
+	movable.MoveBy(2,3)
+
+	if expected, actual := image.Pt(2,3), movable.Offset(); expected != actual {
+		t.Errorf("The actual offset is not what was expected.")
+		t.Logf("EXPECTED: %#v", expected)
+		t.Logf("ACTUAL:   %#v", actual)
+	}
+}
@@ -0,0 +1,216 @@
+package imagerelocate
+
+import (
+	"errors"
+	"image"
+	"image/color"
+)
+
+var errNotNinePatch = errors.New("imagerelocate: image does not have nine-patch border markers")
+
+// SliceMode is how the edges and center of a NineSlice fill their space.
+type SliceMode int
+
+const (
+	// SliceStretch stretches (nearest-neighbor) to fill the space.
+	SliceStretch SliceMode = iota
+
+	// SliceTile repeats to fill the space.
+	SliceTile
+)
+
+// NineSlice is a nine-slice (9-patch) scaled image — for example, for drawing UI panels of any size from one small source image.
+//
+// ‘Source’ is cut into nine pieces by ‘Insets’ (which are distances from the edges of the bounds of ‘Source’).
+// Drawn into ‘Target’:
+// the four corners are relocated as they are;
+// the four edges are stretched or tiled (see ‘EdgeMode’) along one axis;
+// and the center is stretched or tiled (see ‘CenterMode’) along both axes.
+//
+// NineSlice is itself an image.Image, whose bounds are ‘Target’. Everything is computed lazily, when .At() is called.
+type NineSlice struct {
+	Source     image.Image
+	Insets     Insets
+	Target     image.Rectangle
+	EdgeMode   SliceMode
+	CenterMode SliceMode
+}
+
+var _ image.Image = NineSlice{}
+
+func (receiver NineSlice) At(x, y int) color.Color {
+	if nil == receiver.Source || !(image.Point{x,y}).In(receiver.Target) {
+		return color.Transparent
+	}
+
+	source := receiver.Source.Bounds()
+	if source.Empty() {
+		return color.Transparent
+	}
+	target := receiver.Target
+	insets := receiver.Insets
+
+	middleX := inMiddle(x, target.Min.X, target.Max.X, insets.Left, insets.Right)
+	middleY := inMiddle(y, target.Min.Y, target.Max.Y, insets.Top, insets.Bottom)
+
+	modeX, modeY := receiver.EdgeMode, receiver.EdgeMode
+	if middleX && middleY {
+		modeX, modeY = receiver.CenterMode, receiver.CenterMode
+	}
+
+	sx := sliceAxis(x, target.Min.X, target.Max.X, source.Min.X, source.Max.X, insets.Left, insets.Right, modeX)
+	sy := sliceAxis(y, target.Min.Y, target.Max.Y, source.Min.Y, source.Max.Y, insets.Top, insets.Bottom, modeY)
+
+	return receiver.Source.At(sx,sy)
+}
+
+func (receiver NineSlice) Bounds() image.Rectangle {
+	return receiver.Target
+}
+
+func (receiver NineSlice) ColorModel() color.Model {
+	if nil == receiver.Source {
+		return color.RGBA64Model
+	}
+
+	return receiver.Source.ColorModel()
+}
+
+// inMiddle returns whether ‘t’ is in the middle (stretchable) part of [‘tMin’,‘tMax’), given the ‘start’ and ‘end’ insets.
+func inMiddle(t, tMin, tMax, start, end int) bool {
+	return tMin+start <= t && t < tMax-end
+}
+
+// sliceAxis maps the target coordinate ‘t’, in [‘tMin’,‘tMax’), to a source coordinate, in [‘sMin’,‘sMax’), along one axis.
+//
+// The first ‘start’ and last ‘end’ pixels are mapped as they are; the middle is stretched or tiled.
+// The source must not be empty. If the insets are larger than the source, the result is clamped into the source.
+func sliceAxis(t, tMin, tMax, sMin, sMax, start, end int, mode SliceMode) int {
+	return max(sMin, min(sliceAxisUnclamped(t, tMin, tMax, sMin, sMax, start, end, mode), sMax-1))
+}
+
+func sliceAxisUnclamped(t, tMin, tMax, sMin, sMax, start, end int, mode SliceMode) int {
+	targetLength := tMax - tMin
+
+	// If the target is too small for both ends, each end gets its share.
+	if targetLength < start+end {
+		if t-tMin < targetLength*start/(start+end) {
+			return sMin + (t - tMin)
+		}
+		return sMax - (tMax - t)
+	}
+
+	if t < tMin+start {
+		return sMin + (t - tMin)
+	}
+	if tMax-end <= t {
+		return sMax - (tMax - t)
+	}
+
+	sourceMiddle := sMax - sMin - start - end
+	targetMiddle := targetLength - start - end
+	if sourceMiddle <= 0 {
+		// There is no middle to stretch; so the closer end is repeated.
+		// If one of the ends is empty, there is nothing there to repeat, so the other end is used.
+		useStart := t-tMin-start < targetMiddle/2
+		switch {
+		case start <= 0:
+			useStart = false
+		case end <= 0:
+			useStart = true
+		}
+
+		if useStart {
+			return sMin + start - 1
+		}
+		return sMax - end
+	}
+
+	offset := t - (tMin + start)
+
+	switch mode {
+	case SliceTile:
+		return sMin + start + offset%sourceMiddle
+	default:
+		return sMin + start + offset*sourceMiddle/targetMiddle
+	}
+}
+
+// ParseNinePatch reads the border markers of an Android-style nine-patch (.9.png) image.
+//
+// A nine-patch image has a 1 pixel border around its content. Black pixels in the top and left border mark which
+// part of the content stretches; black pixels in the bottom and right border (optionally) mark where content
+// (such as text) placed on top of it goes.
+//
+// ParseNinePatch returns the content (without the border, and keeping its coordinates),
+// the stretch insets (to use as the ‘Insets’ of a NineSlice), and the padding insets.
+// If there are no bottom and right markers, the padding is the same as the stretch insets.
+func ParseNinePatch(img image.Image) (content image.Image, insets Insets, padding Insets, err error) {
+	bounds := img.Bounds()
+	if bounds.Dx() < 3 || bounds.Dy() < 3 {
+		return nil, Insets{}, Insets{}, errNotNinePatch
+	}
+
+	inner := UniformInsets(1).Shrink(bounds)
+
+	horizontal := func(y int) (int, int, bool) {
+		return markerSpan(inner.Min.X, inner.Max.X, func(i int) color.Color {
+			return img.At(i,y)
+		})
+	}
+	vertical := func(x int) (int, int, bool) {
+		return markerSpan(inner.Min.Y, inner.Max.Y, func(i int) color.Color {
+			return img.At(x,i)
+		})
+	}
+
+	left, right, foundX := horizontal(bounds.Min.Y)
+	top, bottom, foundY := vertical(bounds.Min.X)
+	if !foundX || !foundY {
+		return nil, Insets{}, Insets{}, errNotNinePatch
+	}
+
+	insets = Insets{
+		Top:    top - inner.Min.Y,
+		Right:  inner.Max.X - right,
+		Bottom: inner.Max.Y - bottom,
+		Left:   left - inner.Min.X,
+	}
+
+	padding = insets
+	if left, right, found := horizontal(bounds.Max.Y-1); found {
+		padding.Left = left - inner.Min.X
+		padding.Right = inner.Max.X - right
+	}
+	if top, bottom, found := vertical(bounds.Max.X-1); found {
+		padding.Top = top - inner.Min.Y
+		padding.Bottom = inner.Max.Y - bottom
+	}
+
+	return crop(img, inner), insets, padding, nil
+}
+
+// markerSpan returns the span, from the first to (just past) the last, of the black marker pixels in [‘min’,‘max’).
+func markerSpan(min, max int, at func(int) color.Color) (int, int, bool) {
+	var first, last int
+	var found bool
+
+	for i:=min; i<max; i++ {
+		r,g,b,a := at(i).RGBA()
+		if 0xffff != a || 0 != r || 0 != g || 0 != b {
+			continue
+		}
+
+		if !found {
+			first = i
+			found = true
+		}
+		last = i
+	}
+
+	if !found {
+		return 0, 0, false
+	}
+
+	return first, last+1, true
+}
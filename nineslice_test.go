@@ -0,0 +1,191 @@
+package imagerelocate_test
+
+import (
+	"github.com/reiver/go-imagerelocate"
+
+	"image"
+	"image/color"
+
+	"testing"
+)
+
+func TestNineSlice(t *testing.T) {
+
+	// A 5×5 source, where every pixel is a different gray, so that where each pixel came from can be checked.
+	source := image.NewGray(image.Rect(10,10, 15,15))
+	for i := range source.Pix {
+		source.Pix[i] = uint8(i)
+	}
+
+	// A 2×2 source, for insets that are as large as (or larger than) the source.
+	small := image.NewGray(image.Rect(0,0, 2,2))
+	copy(small.Pix, []uint8{10,20, 30,40})
+
+	grayAt := func(img image.Image, x, y int) uint8 {
+		return color.GrayModel.Convert(img.At(x,y)).(color.Gray).Y
+	}
+
+	tests := []struct{
+		NineSlice imagerelocate.NineSlice
+		Points []image.Point
+		ExpectedSources []image.Point
+	}{
+		{
+			NineSlice: imagerelocate.NineSlice{
+				Source: source,
+				Insets: imagerelocate.UniformInsets(1),
+				Target: image.Rect(0,0, 11,8),
+			},
+			Points: []image.Point{
+				{0,0}, {10,0}, {0,7}, {10,7}, // corners
+				{1,0}, {9,0},                 // top edge
+				{1,1}, {9,6},                 // center
+			},
+			ExpectedSources: []image.Point{
+				{10,10}, {14,10}, {10,14}, {14,14},
+				{11,10}, {13,10},
+				{11,11}, {13,13},
+			},
+		},
+		{
+			NineSlice: imagerelocate.NineSlice{
+				Source: source,
+				Insets: imagerelocate.UniformInsets(1),
+				Target: image.Rect(-3,-3, 8,5),
+				EdgeMode: imagerelocate.SliceTile,
+				CenterMode: imagerelocate.SliceTile,
+			},
+			Points: []image.Point{
+				{-3,-3}, {7,4},  // corners
+				{-2,-3}, {1,-3}, // top edge, tiled: 11,12,13,11,...
+				{1,1},           // center, tiled
+			},
+			ExpectedSources: []image.Point{
+				{10,10}, {14,14},
+				{11,10}, {11,10},
+				{11,11},
+			},
+		},
+		{
+			// Zero left inset, and no middle in the source: the right end is repeated.
+			NineSlice: imagerelocate.NineSlice{
+				Source: small,
+				Insets: imagerelocate.Insets{Right:2},
+				Target: image.Rect(0,0, 10,2),
+			},
+			Points: []image.Point{
+				{0,0}, {7,0}, {8,0}, {9,0}, {9,1},
+			},
+			ExpectedSources: []image.Point{
+				{0,0}, {0,0}, {0,0}, {1,0}, {1,1},
+			},
+		},
+		{
+			// Zero right inset: the left end is repeated.
+			NineSlice: imagerelocate.NineSlice{
+				Source: small,
+				Insets: imagerelocate.Insets{Left:2},
+				Target: image.Rect(0,0, 10,2),
+			},
+			Points: []image.Point{
+				{0,0}, {1,0}, {2,0}, {9,0}, {9,1},
+			},
+			ExpectedSources: []image.Point{
+				{0,0}, {1,0}, {1,0}, {1,0}, {1,1},
+			},
+		},
+		{
+			// Insets larger than the source: clamped into the source.
+			NineSlice: imagerelocate.NineSlice{
+				Source: small,
+				Insets: imagerelocate.Insets{Right:5, Bottom:3},
+				Target: image.Rect(0,0, 10,4),
+			},
+			Points: []image.Point{
+				{0,0}, {4,0}, {5,0}, {8,0}, {9,0}, {9,3}, {0,3},
+			},
+			ExpectedSources: []image.Point{
+				{0,0}, {0,0}, {0,0}, {0,0}, {1,0}, {1,1}, {0,1},
+			},
+		},
+		{
+			// Empty source: transparent.
+			NineSlice: imagerelocate.NineSlice{
+				Source: image.NewGray(image.Rectangle{}),
+				Insets: imagerelocate.UniformInsets(1),
+				Target: image.Rect(0,0, 4,4),
+			},
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		if expected, actual := test.NineSlice.Target, test.NineSlice.Bounds(); expected != actual {
+			t.Errorf("For test #%d, the actual bounds are not what was expected.", testNumber)
+			t.Logf("EXPECTED: %#v", expected)
+			t.Logf("ACTUAL:   %#v", actual)
+			continue
+		}
+
+		if test.NineSlice.Source.Bounds().Empty() {
+			if expected, actual := (color.RGBA64{}), color.RGBA64Model.Convert(test.NineSlice.At(1,1)); expected != actual {
+				t.Errorf("For test #%d, expected transparent from an empty source.", testNumber)
+				t.Logf("ACTUAL: %#v", actual)
+			}
+			continue
+		}
+
+		for i, p := range test.Points {
+			q := test.ExpectedSources[i]
+
+			if expected, actual := grayAt(test.NineSlice.Source, q.X, q.Y), grayAt(test.NineSlice, p.X, p.Y); expected != actual {
+				t.Errorf("For test #%d, the actual color at (%d,%d) is not what was expected.", testNumber, p.X, p.Y)
+				t.Logf("EXPECTED: %d (from (%d,%d))", expected, q.X, q.Y)
+				t.Logf("ACTUAL:   %d", actual)
+				continue
+			}
+		}
+	}
+}
+
+func TestParseNinePatch(t *testing.T) {
+
+	black := color.RGBA{A:255}
+
+	// A 6×5 nine-patch, with a 4×3 content.
+	img := image.NewRGBA(image.Rect(0,0, 6,5))
+	img.Set(2,0, black) // stretchable x: [2,4)
+	img.Set(3,0, black)
+	img.Set(0,2, black) // stretchable y: [2,3)
+	img.Set(1,4, black) // content x: [1,5)
+	img.Set(4,4, black)
+
+	content, insets, padding, err := imagerelocate.ParseNinePatch(img)
+	if nil != err {
+		t.Errorf("Did not expect an error but actually got one.")
+		t.Logf("ERROR: (%T) %s", err, err)
+		return
+	}
+
+	if expected, actual := image.Rect(1,1, 5,4), content.Bounds(); expected != actual {
+		t.Errorf("The actual content bounds are not what was expected.")
+		t.Logf("EXPECTED: %#v", expected)
+		t.Logf("ACTUAL:   %#v", actual)
+	}
+
+	if expected, actual := (imagerelocate.Insets{Top:1, Right:1, Bottom:1, Left:1}), insets; expected != actual {
+		t.Errorf("The actual insets are not what was expected.")
+		t.Logf("EXPECTED: %#v", expected)
+		t.Logf("ACTUAL:   %#v", actual)
+	}
+
+	if expected, actual := (imagerelocate.Insets{Top:1, Right:0, Bottom:1, Left:0}), padding; expected != actual {
+		t.Errorf("The actual padding is not what was expected.")
+		t.Logf("EXPECTED: %#v", expected)
+		t.Logf("ACTUAL:   %#v", actual)
+	}
+
+	if _, _, _, err := imagerelocate.ParseNinePatch(image.NewRGBA(image.Rect(0,0, 6,5))); nil == err {
+		t.Errorf("Expected an error for an image without markers, but did not actually get one.")
+	}
+}
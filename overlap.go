@@ -0,0 +1,70 @@
+package imagerelocate
+
+import (
+	"image"
+)
+
+// Overlaps returns whether ‘a’ and ‘b’ collide — i.e., whether there is at least one (x,y) where
+// both ‘a’ and ‘b’ have a pixel whose (8-bit) alpha is greater than ‘alphaThreshold’.
+//
+// ‘a’ and ‘b’ are expected to already be placed (for example with Wrap) in the same coordinate space.
+//
+// Only the intersection of the bounds of ‘a’ and ‘b’ is checked, so false positives from
+// only comparing bounding boxes do not happen.
+//
+// At least one of ‘a’ and ‘b’ must be bounded (see IsUnbounded).
+//
+// For checking the same images many times, see HitMask.
+func Overlaps(a, b image.Image, alphaThreshold uint8) bool {
+	var overlaps bool
+
+	overlapScan(a, b, alphaThreshold, func(x, y int) bool {
+		overlaps = true
+		return false
+	})
+
+	return overlaps
+}
+
+// OverlapRect returns the tightest rectangle containing every (x,y) where ‘a’ and ‘b’ collide (see Overlaps).
+//
+// If ‘a’ and ‘b’ do not collide, then an empty rectangle is returned.
+func OverlapRect(a, b image.Image, alphaThreshold uint8) image.Rectangle {
+	var overlap image.Rectangle
+
+	overlapScan(a, b, alphaThreshold, func(x, y int) bool {
+		overlap = overlap.Union(image.Rect(x,y, x+1,y+1))
+		return true
+	})
+
+	return overlap
+}
+
+// overlapScan calls ‘fn’ for each (x,y) where ‘a’ and ‘b’ collide, until ‘fn’ returns false.
+func overlapScan(a, b image.Image, alphaThreshold uint8, fn func(x, y int) bool) {
+	var intersection image.Rectangle = clip(a, clip(b, a.Bounds()))
+	if intersection.Empty() {
+		return
+	}
+
+	srcA, offsetA := unwrap(a)
+	srcB, offsetB := unwrap(b)
+
+	alphaA := alphaFunc(srcA)
+	alphaB := alphaFunc(srcB)
+
+	for y:=intersection.Min.Y; y<intersection.Max.Y; y++ {
+		for x:=intersection.Min.X; x<intersection.Max.X; x++ {
+			if alphaA(x-offsetA.X, y-offsetA.Y) <= alphaThreshold {
+				continue
+			}
+			if alphaB(x-offsetB.X, y-offsetB.Y) <= alphaThreshold {
+				continue
+			}
+
+			if !fn(x,y) {
+				return
+			}
+		}
+	}
+}
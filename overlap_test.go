@@ -0,0 +1,105 @@
+package imagerelocate_test
+
+import (
+	"github.com/reiver/go-imagerelocate"
+
+	"image"
+	"image/color"
+
+	"testing"
+)
+
+func TestOverlaps(t *testing.T) {
+
+	// A diagonal line, from the top-left to the bottom-right.
+	backslash := image.NewNRGBA(image.Rect(0,0, 71,71))
+	for i:=0; i<71; i++ {
+		backslash.Set(i,i, color.NRGBA{A:255})
+	}
+
+	// A diagonal line, from the top-right to the bottom-left.
+	slash := image.NewNRGBA(image.Rect(0,0, 71,71))
+	for i:=0; i<71; i++ {
+		slash.Set(70-i,i, color.NRGBA{A:255})
+	}
+
+	tests := []struct{
+		A image.Image
+		B image.Image
+		Expected image.Rectangle
+	}{
+		{
+			// The bounding boxes overlap, but the pixels do not.
+			A: backslash,
+			B: imagerelocate.Wrap(40,-40, backslash),
+			Expected: image.Rectangle{},
+		},
+		{
+			A: backslash,
+			B: slash,
+			Expected: image.Rect(35,35, 36,36),
+		},
+		{
+			A: imagerelocate.Wrap(5,0, backslash),
+			B: backslash,
+			Expected: image.Rectangle{},
+		},
+		{
+			A: imagerelocate.Wrap(2,0, slash),
+			B: backslash,
+			Expected: image.Rect(36,36, 37,37),
+		},
+		{
+			// The bounding boxes do not overlap.
+			A: backslash,
+			B: imagerelocate.Wrap(71,0, slash),
+			Expected: image.Rectangle{},
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		if expected, actual := !test.Expected.Empty(), imagerelocate.Overlaps(test.A, test.B, 0); expected != actual {
+			t.Errorf("For test #%d, the actual overlaps value is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %t", expected)
+			t.Logf("ACTUAL:   %t", actual)
+			continue
+		}
+
+		if expected, actual := test.Expected, imagerelocate.OverlapRect(test.A, test.B, 0); expected != actual {
+			t.Errorf("For test #%d, the actual overlap rectangle is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %#v", expected)
+			t.Logf("ACTUAL:   %#v", actual)
+			continue
+		}
+
+		maskA := imagerelocate.NewHitMask(test.A, 0)
+		maskB := imagerelocate.NewHitMask(test.B, 0)
+
+		if expected, actual := !test.Expected.Empty(), maskA.Overlaps(maskB); expected != actual {
+			t.Errorf("For test #%d, the actual hit-mask overlaps value is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %t", expected)
+			t.Logf("ACTUAL:   %t", actual)
+			continue
+		}
+
+		if expected, actual := test.Expected, maskA.OverlapRect(maskB); expected != actual {
+			t.Errorf("For test #%d, the actual hit-mask overlap rectangle is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %#v", expected)
+			t.Logf("ACTUAL:   %#v", actual)
+			continue
+		}
+
+		// Moving both masks the same amount should move the overlap the same amount.
+		var expectedMoved image.Rectangle
+		if !test.Expected.Empty() {
+			expectedMoved = test.Expected.Add(image.Pt(-3,9))
+		}
+		if expected, actual := expectedMoved, maskA.Moved(-3,9).OverlapRect(maskB.Moved(-3,9)); expected != actual {
+			t.Errorf("For test #%d, the actual moved hit-mask overlap rectangle is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %#v", expected)
+			t.Logf("ACTUAL:   %#v", actual)
+			continue
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package imagerelocate
+
+import (
+	"image"
+	"image/color"
+)
+
+// Pad returns a view of ‘img’ with a margin of ‘fill’ around it — for example, for drop shadows,
+// or to line up a grid of thumbnails.
+//
+// The bounds of the returned image are the bounds of ‘img’ grown by ‘insets’. Inside the bounds of ‘img’
+// the pixels of ‘img’ are returned; in the margin ‘fill’ is returned. (A nil ‘fill’ is transparent.)
+//
+// The color model of the returned image is that of ‘img’, unless it cannot represent ‘fill’ —
+// in which case it is color.RGBA64Model.
+//
+// Coordinates are not changed, so padding a relocated image keeps its relocation.
+// No pixels are copied.
+func Pad(img image.Image, insets Insets, fill color.Color) image.Image {
+	if nil == fill {
+		fill = color.Transparent
+	}
+
+	return internalPaddedImage{
+		img:  img,
+		rect: insets.Grow(img.Bounds()),
+		fill: fill,
+	}
+}
+
+// Inset returns a view of ‘img’ with ‘insets’ cut off of its edges.
+//
+// Coordinates are not changed, so insetting a relocated image keeps its relocation.
+// No pixels are copied.
+func Inset(img image.Image, insets Insets) image.Image {
+	return crop(img, insets.Shrink(img.Bounds()))
+}
+
+// internalPaddedImage is the image returned from Pad.
+type internalPaddedImage struct {
+	img  image.Image
+	rect image.Rectangle
+	fill color.Color
+}
+
+func (receiver internalPaddedImage) At(x, y int) color.Color {
+	p := image.Point{x,y}
+
+	if !p.In(receiver.rect) {
+		return color.Transparent
+	}
+
+	if !p.In(receiver.img.Bounds()) {
+		return receiver.fill
+	}
+
+	return receiver.img.At(x,y)
+}
+
+func (receiver internalPaddedImage) Bounds() image.Rectangle {
+	return receiver.rect
+}
+
+func (receiver internalPaddedImage) ColorModel() color.Model {
+	model := receiver.img.ColorModel()
+	if !representable(model, receiver.fill) {
+		return color.RGBA64Model
+	}
+
+	return model
+}
+
+// representable returns whether ‘model’ can represent ‘c’ without changing it.
+func representable(model color.Model, c color.Color) bool {
+	if nil == model {
+		return false
+	}
+
+	r,g,b,a := c.RGBA()
+	mr,mg,mb,ma := model.Convert(c).RGBA()
+
+	return r == mr && g == mg && b == mb && a == ma
+}
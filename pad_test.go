@@ -0,0 +1,130 @@
+package imagerelocate_test
+
+import (
+	"github.com/reiver/go-imagerelocate"
+
+	"image"
+	"image/color"
+
+	"testing"
+)
+
+func TestPad_and_Inset(t *testing.T) {
+
+	red := color.RGBA{R:255, A:255}
+	blue := color.RGBA{B:255, A:255}
+
+	src := image.NewRGBA(image.Rect(0,0, 4,4))
+	for y:=0; y<4; y++ {
+		for x:=0; x<4; x++ {
+			src.Set(x,y, red)
+		}
+	}
+
+	insets := imagerelocate.Insets{Top:1, Right:2, Bottom:3, Left:4}
+
+	tests := []struct{
+		Image image.Image
+		Expected image.Rectangle
+		Inside image.Point
+		InsideColor color.Color
+		Outside image.Point
+		OutsideColor color.Color
+	}{
+		{
+			Image: imagerelocate.Pad(src, insets, blue),
+			Expected: image.Rect(-4,-1, 6,7),
+			Inside: image.Pt(0,0),
+			InsideColor: red,
+			Outside: image.Pt(-4,-1),
+			OutsideColor: blue,
+		},
+		{
+			Image: imagerelocate.Pad(imagerelocate.Wrap(10,20, src), insets, nil),
+			Expected: image.Rect(6,19, 16,27),
+			Inside: image.Pt(13,23),
+			InsideColor: red,
+			Outside: image.Pt(15,26),
+			OutsideColor: color.Transparent,
+		},
+		{
+			Image: imagerelocate.Inset(imagerelocate.Wrap(10,20, src), imagerelocate.UniformInsets(1)),
+			Expected: image.Rect(11,21, 13,23),
+			Inside: image.Pt(12,22),
+			InsideColor: red,
+			Outside: image.Pt(10,20),
+			OutsideColor: color.Transparent,
+		},
+		{
+			Image: imagerelocate.Inset(src, insets),
+			Expected: image.Rectangle{},
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		if expected, actual := test.Expected, test.Image.Bounds(); expected != actual {
+			t.Errorf("For test #%d, the actual bounds are not what was expected.", testNumber)
+			t.Logf("EXPECTED: %#v", expected)
+			t.Logf("ACTUAL:   %#v", actual)
+			continue
+		}
+
+		if nil != test.InsideColor {
+			if expected, actual := color.RGBA64Model.Convert(test.InsideColor), color.RGBA64Model.Convert(test.Image.At(test.Inside.X, test.Inside.Y)); expected != actual {
+				t.Errorf("For test #%d, the actual inside color is not what was expected.", testNumber)
+				t.Logf("EXPECTED: %#v", expected)
+				t.Logf("ACTUAL:   %#v", actual)
+				continue
+			}
+		}
+
+		if nil != test.OutsideColor {
+			if expected, actual := color.RGBA64Model.Convert(test.OutsideColor), color.RGBA64Model.Convert(test.Image.At(test.Outside.X, test.Outside.Y)); expected != actual {
+				t.Errorf("For test #%d, the actual outside color is not what was expected.", testNumber)
+				t.Logf("EXPECTED: %#v", expected)
+				t.Logf("ACTUAL:   %#v", actual)
+				continue
+			}
+		}
+	}
+}
+
+func TestPad_colorModel(t *testing.T) {
+
+	gray := image.NewGray(image.Rect(0,0, 4,4))
+
+	tests := []struct{
+		Fill color.Color
+		Expected color.Model
+	}{
+		{
+			Fill: color.Gray{Y:128},
+			Expected: color.GrayModel,
+		},
+		{
+			Fill: color.Black,
+			Expected: color.GrayModel,
+		},
+		{
+			// Gray cannot represent blue, nor transparency.
+			Fill: color.RGBA{B:255, A:255},
+			Expected: color.RGBA64Model,
+		},
+		{
+			Fill: nil,
+			Expected: color.RGBA64Model,
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		padded := imagerelocate.Pad(gray, imagerelocate.UniformInsets(2), test.Fill)
+
+		if expected, actual := test.Expected, padded.ColorModel(); expected != actual {
+			t.Errorf("For test #%d, the actual color model is not what was expected.", testNumber)
+			t.Logf("FILL: %#v", test.Fill)
+			continue
+		}
+	}
+}
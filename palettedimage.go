@@ -0,0 +1,22 @@
+package imagerelocate
+
+import (
+	"image"
+)
+
+// internalPalettedImage is a relocated image.PalettedImage.
+//
+// It is just like internalImage, except that it also has a .ColorIndexAt() method,
+// so that it too is an image.PalettedImage.
+type internalPalettedImage struct {
+	internalImage
+}
+
+var _ image.PalettedImage = internalPalettedImage{}
+
+func (receiver internalPalettedImage) ColorIndexAt(x, y int) uint8 {
+	x -= receiver.x
+	y -= receiver.y
+
+	return receiver.img.(image.PalettedImage).ColorIndexAt(x,y)
+}
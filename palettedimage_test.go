@@ -0,0 +1,62 @@
+package imagerelocate_test
+
+import (
+	"github.com/reiver/go-imagerelocate"
+
+	"image"
+	"image/color"
+
+	"testing"
+)
+
+func TestWrap_paletted(t *testing.T) {
+
+	palette := color.Palette{
+		color.RGBA{A:255},
+		color.RGBA{R:255, A:255},
+		color.RGBA{G:255, A:255},
+	}
+
+	src := image.NewPaletted(image.Rect(0,0, 2,2), palette)
+	src.SetColorIndex(0,0, 1)
+	src.SetColorIndex(1,1, 2)
+
+	tests := []struct{
+		DX int
+		DY int
+	}{
+		{
+			DX: 0,
+			DY: 0,
+		},
+		{
+			DX: 10,
+			DY: -3,
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		img, casted := imagerelocate.Wrap(test.DX, test.DY, src).(image.PalettedImage)
+		if !casted {
+			t.Errorf("For test #%d, expected the relocated image to be an image.PalettedImage, but it wasn't.", testNumber)
+			continue
+		}
+
+		if _, casted := img.ColorModel().(color.Palette); !casted {
+			t.Errorf("For test #%d, expected the color model to be a color.Palette, but it wasn't.", testNumber)
+			t.Logf("COLOR-MODEL: %#v", img.ColorModel())
+			continue
+		}
+
+		for y:=0; y<2; y++ {
+			for x:=0; x<2; x++ {
+				if expected, actual := src.ColorIndexAt(x,y), img.ColorIndexAt(x+test.DX, y+test.DY); expected != actual {
+					t.Errorf("For test #%d, the actual color index for (%d,%d) is not what was expected.", testNumber, x,y)
+					t.Logf("EXPECTED: %d", expected)
+					t.Logf("ACTUAL:   %d", actual)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package imagerelocate
+
+import (
+	"image"
+	"image/color"
+	"math"
+)
+
+// ParallaxLayer is one layer of a Parallax.
+//
+// ‘Factor’ is how fast the layer scrolls relative to the camera.
+// A ‘Factor’ of 0.0 means the layer does not scroll at all (for example, a far away sky);
+// a ‘Factor’ of 1.0 means the layer scrolls with the camera (for example, the playfield).
+//
+// If ‘TileX’ is true, then ‘Image’ repeats horizontally.
+// If ‘TileY’ is true, then ‘Image’ repeats vertically.
+type ParallaxLayer struct {
+	Image  image.Image
+	Factor float64
+	TileX  bool
+	TileY  bool
+}
+
+// Parallax composites several layers, each of which scrolls at its own rate, for a single camera offset.
+//
+// It is itself an image.Image, whose bounds are ‘Screen’.
+// The layers are composited lazily, when .At() is called.
+// To draw a whole frame, use .Frame(), which works out each layer's offset once rather than for each pixel.
+//
+// ‘Layers’ go from back to front — i.e., ‘Layers[0]’ is the furthest back layer.
+//
+// ‘Camera’ is the camera offset (the scroll position). Each layer is relocated by
+// the negative of ‘Camera’ scaled by the layer's ‘Factor’.
+type Parallax struct {
+	Layers []ParallaxLayer
+	Camera image.Point
+	Screen image.Rectangle
+}
+
+var _ image.Image = Parallax{}
+
+// LayerOffset returns the relocation used for the layer at ‘index’ for the current ‘Camera’.
+func (receiver Parallax) LayerOffset(index int) image.Point {
+	factor := receiver.Layers[index].Factor
+
+	return image.Point{
+		X: -int(math.Round(float64(receiver.Camera.X) * factor)),
+		Y: -int(math.Round(float64(receiver.Camera.Y) * factor)),
+	}
+}
+
+// Layer returns the layer at ‘index’, tiled (if requested) and relocated for the current ‘Camera’.
+func (receiver Parallax) Layer(index int) image.Image {
+	layer := receiver.Layers[index]
+
+	var img image.Image = layer.Image
+	if layer.TileX || layer.TileY {
+		img = internalTiledImage{
+			img:   img,
+			tileX: layer.TileX,
+			tileY: layer.TileY,
+		}
+	}
+
+	offset := receiver.LayerOffset(index)
+
+	return Wrap(offset.X, offset.Y, img)
+}
+
+// Frame returns the composited image for the current ‘Camera’.
+//
+// Each layer's offset is worked out once, when Frame is called; so the returned image does not change
+// if the parallax is changed afterwards (although it does if the layer images change).
+func (receiver Parallax) Frame() image.Image {
+	layers := make([]parallaxLayerFrame, 0, len(receiver.Layers))
+	for index, layer := range receiver.Layers {
+		if nil == layer.Image {
+			continue
+		}
+
+		layers = append(layers, receiver.layerFrame(index))
+	}
+
+	return internalParallaxFrame{
+		layers: layers,
+		screen: receiver.Screen,
+	}
+}
+
+func (receiver Parallax) layerFrame(index int) parallaxLayerFrame {
+	layer := receiver.Layers[index]
+
+	return parallaxLayerFrame{
+		img: internalTiledImage{
+			img:   layer.Image,
+			tileX: layer.TileX,
+			tileY: layer.TileY,
+		},
+		offset: receiver.LayerOffset(index),
+	}
+}
+
+func (receiver Parallax) At(x, y int) color.Color {
+	p := image.Point{x,y}
+
+	if !p.In(receiver.Screen) {
+		return color.Transparent
+	}
+
+	var c color.RGBA64
+
+	for index, layer := range receiver.Layers {
+		if nil == layer.Image {
+			continue
+		}
+
+		if layerColor, ok := receiver.layerFrame(index).at(p); ok {
+			c = over(c, layerColor)
+		}
+	}
+
+	return c
+}
+
+func (receiver Parallax) Bounds() image.Rectangle {
+	return receiver.Screen
+}
+
+func (receiver Parallax) ColorModel() color.Model {
+	return color.RGBA64Model
+}
+
+// parallaxLayerFrame is one layer of a Parallax, tiled (if requested), with its offset worked out.
+type parallaxLayerFrame struct {
+	img    internalTiledImage
+	offset image.Point
+}
+
+// at returns the color of the layer at ‘p’ (in screen coordinates), and whether ‘p’ is inside of the layer.
+func (receiver parallaxLayerFrame) at(p image.Point) (color.Color, bool) {
+	p = p.Sub(receiver.offset)
+
+	if !receiver.img.Unbounded() && !p.In(receiver.img.Bounds()) {
+		return nil, false
+	}
+
+	return receiver.img.At(p.X, p.Y), true
+}
+
+// internalParallaxFrame is the image returned from Parallax.Frame.
+type internalParallaxFrame struct {
+	layers []parallaxLayerFrame
+	screen image.Rectangle
+}
+
+func (receiver internalParallaxFrame) At(x, y int) color.Color {
+	p := image.Point{x,y}
+
+	if !p.In(receiver.screen) {
+		return color.Transparent
+	}
+
+	var c color.RGBA64
+
+	for _, layer := range receiver.layers {
+		if layerColor, ok := layer.at(p); ok {
+			c = over(c, layerColor)
+		}
+	}
+
+	return c
+}
+
+func (receiver internalParallaxFrame) Bounds() image.Rectangle {
+	return receiver.screen
+}
+
+func (receiver internalParallaxFrame) ColorModel() color.Model {
+	return color.RGBA64Model
+}
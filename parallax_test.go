@@ -0,0 +1,140 @@
+package imagerelocate_test
+
+import (
+	"github.com/reiver/go-imagerelocate"
+
+	"image"
+	"image/color"
+
+	"testing"
+)
+
+func TestParallax(t *testing.T) {
+
+	red   := color.RGBA64{R:0xffff, A:0xffff}
+	green := color.RGBA64{G:0xffff, A:0xffff}
+
+	// A 4×1 sky, which is red in its left-most pixel and transparent otherwise.
+	sky := image.NewRGBA64(image.Rect(0,0, 4,1))
+	sky.SetRGBA64(0,0, red)
+
+	// A single green pixel at (8,0).
+	ground := image.NewRGBA64(image.Rect(8,0, 9,1))
+	ground.SetRGBA64(8,0, green)
+
+	parallax := imagerelocate.Parallax{
+		Layers: []imagerelocate.ParallaxLayer{
+			{
+				Image:  sky,
+				Factor: 0.5,
+				TileX:  true,
+			},
+			{
+				Image:  ground,
+				Factor: 1.0,
+			},
+		},
+		Camera: image.Pt(6,0),
+		Screen: image.Rect(0,0, 8,1),
+	}
+
+	// The sky moved by -3, so its red pixels are at x = ... -3, 1, 5, ...
+	// The ground moved by -6, so its green pixel is at x = 2.
+	expected := []color.RGBA64{
+		{},
+		red,
+		green,
+		{},
+		{},
+		red,
+		{},
+		{},
+	}
+
+	for x, expectedColor := range expected {
+
+		if expected, actual := expectedColor, parallax.At(x,0); expected != actual {
+			t.Errorf("For x=%d, the actual color is not what was expected.", x)
+			t.Logf("EXPECTED: %#v", expected)
+			t.Logf("ACTUAL:   %#v", actual)
+			continue
+		}
+	}
+}
+
+func TestParallax_Frame(t *testing.T) {
+
+	sky := image.NewRGBA64(image.Rect(0,0, 4,3))
+	for i := range sky.Pix {
+		sky.Pix[i] = uint8(i*7)
+	}
+	for y:=0; y<3; y++ {
+		for x:=0; x<4; x++ {
+			c := sky.RGBA64At(x,y)
+			c.A = 0xffff
+			sky.SetRGBA64(x,y, c)
+		}
+	}
+
+	ground := image.NewRGBA64(image.Rect(8,0, 12,2))
+	ground.SetRGBA64(9,1, color.RGBA64{G:0xffff, A:0x8000})
+
+	parallax := imagerelocate.Parallax{
+		Layers: []imagerelocate.ParallaxLayer{
+			{
+				Image:  sky,
+				Factor: 0.5,
+				TileX:  true,
+				TileY:  true,
+			},
+			{
+				Image:  nil,
+			},
+			{
+				Image:  ground,
+				Factor: 1.0,
+				TileY:  true,
+			},
+		},
+		Camera: image.Pt(5,-3),
+		Screen: image.Rect(0,0, 16,6),
+	}
+
+	frame := parallax.Frame()
+
+	if expected, actual := parallax.Bounds(), frame.Bounds(); expected != actual {
+		t.Errorf("The actual frame bounds are not what was expected.")
+		t.Logf("EXPECTED: %#v", expected)
+		t.Logf("ACTUAL:   %#v", actual)
+	}
+
+	for y:=-1; y<7; y++ {
+		for x:=-1; x<17; x++ {
+			if expected, actual := parallax.At(x,y), frame.At(x,y); expected != actual {
+				t.Errorf("The actual color at (%d,%d) is not what was expected.", x, y)
+				t.Logf("EXPECTED: %#v", expected)
+				t.Logf("ACTUAL:   %#v", actual)
+				return
+			}
+		}
+	}
+
+	// Looking up a pixel should not allocate, other than for the returned color.
+	uniform := imagerelocate.Parallax{
+		Layers: []imagerelocate.ParallaxLayer{
+			{
+				Image:  image.NewUniform(color.White),
+				Factor: 0.5,
+			},
+			{
+				Image:  image.NewUniform(color.RGBA64{G:0x8000, A:0x8000}),
+				Factor: 1.0,
+			},
+		},
+		Camera: image.Pt(5,-3),
+		Screen: image.Rect(0,0, 640,480),
+	}
+	if allocs := testing.AllocsPerRun(100, func(){ uniform.At(3,2) }); 1 < allocs {
+		t.Errorf("Expected .At() to allocate at most once, but it allocated %v times.", allocs)
+	}
+}
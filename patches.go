@@ -0,0 +1,188 @@
+package imagerelocate
+
+import (
+	"image"
+	"image/color"
+	"iter"
+	"math/rand"
+)
+
+// PadPolicy is what Patches does with windows that go past the edge of the image.
+type PadPolicy int
+
+const (
+	// PadSkip skips windows that go past the edge of the image.
+	PadSkip PadPolicy = iota
+
+	// PadTransparent keeps windows that go past the edge of the image, and makes the part past the edge transparent.
+	PadTransparent
+
+	// PadClamp keeps windows that go past the edge of the image, and repeats the pixels on the edge of the image past it.
+	PadClamp
+)
+
+// PatchOptions are the options for Patches and RandomPatches.
+//
+// ‘Size’ is the size of each window (patch).
+//
+// ‘Stride’ is how far apart windows are. A ‘Stride’ smaller than ‘Size’ makes overlapping windows.
+// If either coordinate of ‘Stride’ is zero (or negative), the same coordinate of ‘Size’ is used instead.
+//
+// ‘Padding’ is what to do with windows that go past the edge of the image.
+//
+// If ‘Normalize’ is true, each patch is relocated so that its top-left corner is at (0,0);
+// otherwise each patch keeps the coordinates of the image it came from.
+type PatchOptions struct {
+	Size      image.Point
+	Stride    image.Point
+	Padding   PadPolicy
+	Normalize bool
+}
+
+// Patch is one window cut from an image.
+//
+// ‘Offset’ is the top-left corner of the window, in the coordinates of the image it came from.
+type Patch struct {
+	Image  image.Image
+	Offset image.Point
+}
+
+// Patches returns an iterator over (possibly overlapping) windows of ‘img’, from top to bottom, and left to right —
+// for example, for cutting images into patches for machine learning training data.
+//
+// The patches are views into ‘img’; no pixels are copied.
+//
+// ‘img’ must be bounded (see IsUnbounded).
+func Patches(img image.Image, opts PatchOptions) iter.Seq[Patch] {
+	return func(yield func(Patch) bool) {
+		size := opts.Size
+		if size.X <= 0 || size.Y <= 0 {
+			return
+		}
+
+		stride := opts.Stride
+		if stride.X <= 0 {
+			stride.X = size.X
+		}
+		if stride.Y <= 0 {
+			stride.Y = size.Y
+		}
+
+		bounds := img.Bounds()
+
+		// With PadSkip, windows have to fit; otherwise they only have to start inside of the image.
+		last := bounds.Max.Sub(image.Point{1,1})
+		if PadSkip == opts.Padding {
+			last = bounds.Max.Sub(size)
+		}
+
+		for y:=bounds.Min.Y; y<=last.Y; y+=stride.Y {
+			for x:=bounds.Min.X; x<=last.X; x+=stride.X {
+				if !yield(newPatch(img, image.Point{x,y}, opts)) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// RandomPatches returns an iterator over ‘count’ windows of ‘img’ at random positions — for random-crop augmentation.
+//
+// Only ‘Size’ and ‘Normalize’ of ‘opts’ are used. Each window is entirely inside of ‘img’.
+// If ‘img’ is smaller than ‘Size’, then nothing is yielded.
+//
+// The positions come from ‘source’; so the same seed results in the same patches.
+//
+// ‘img’ must be bounded (see IsUnbounded).
+func RandomPatches(img image.Image, opts PatchOptions, count int, source rand.Source) iter.Seq[Patch] {
+	return func(yield func(Patch) bool) {
+		size := opts.Size
+		if size.X <= 0 || size.Y <= 0 {
+			return
+		}
+
+		bounds := img.Bounds()
+
+		spanX := bounds.Dx() - size.X + 1
+		spanY := bounds.Dy() - size.Y + 1
+		if spanX <= 0 || spanY <= 0 {
+			return
+		}
+
+		randomness := rand.New(source)
+
+		for i:=0; i<count; i++ {
+			offset := image.Point{
+				X: bounds.Min.X + randomness.Intn(spanX),
+				Y: bounds.Min.Y + randomness.Intn(spanY),
+			}
+
+			if !yield(newPatch(img, offset, opts)) {
+				return
+			}
+		}
+	}
+}
+
+func newPatch(img image.Image, offset image.Point, opts PatchOptions) Patch {
+	window := image.Rectangle{Min:offset, Max:offset.Add(opts.Size)}
+
+	var patch image.Image
+	if window.In(img.Bounds()) {
+		patch = crop(img, window)
+	} else {
+		patch = internalWindowImage{
+			img:   img,
+			rect:  window,
+			clamp: PadClamp == opts.Padding,
+		}
+	}
+
+	if opts.Normalize {
+		patch = Wrap(-offset.X, -offset.Y, patch)
+	}
+
+	return Patch{
+		Image:  patch,
+		Offset: offset,
+	}
+}
+
+// internalWindowImage is a view of an image through a window that can go past the edge of the image.
+//
+// Past the edge, it is either transparent, or (if ‘clamp’ is true) repeats the pixels on the edge of the image.
+type internalWindowImage struct {
+	img   image.Image
+	rect  image.Rectangle
+	clamp bool
+}
+
+func (receiver internalWindowImage) At(x, y int) color.Color {
+	p := image.Point{x,y}
+
+	if !p.In(receiver.rect) {
+		return color.Transparent
+	}
+
+	bounds := receiver.img.Bounds()
+	if p.In(bounds) {
+		return receiver.img.At(x,y)
+	}
+
+	if !receiver.clamp || bounds.Empty() {
+		return color.Transparent
+	}
+
+	x = max(bounds.Min.X, min(x, bounds.Max.X-1))
+	y = max(bounds.Min.Y, min(y, bounds.Max.Y-1))
+
+	return receiver.img.At(x,y)
+}
+
+func (receiver internalWindowImage) Bounds() image.Rectangle {
+	return receiver.rect
+}
+
+func (receiver internalWindowImage) ColorModel() color.Model {
+	return receiver.img.ColorModel()
+}
@@ -0,0 +1,159 @@
+package imagerelocate_test
+
+import (
+	"github.com/reiver/go-imagerelocate"
+
+	"image"
+	"image/color"
+	"math/rand"
+	"reflect"
+
+	"testing"
+)
+
+func TestPatches(t *testing.T) {
+
+	src := image.NewGray(image.Rect(10,20, 15,24))
+	for i := range src.Pix {
+		src.Pix[i] = uint8(1+i)
+	}
+
+	tests := []struct{
+		Options imagerelocate.PatchOptions
+		ExpectedOffsets []image.Point
+		Check image.Point
+		ExpectedCheck color.Color
+	}{
+		{
+			Options: imagerelocate.PatchOptions{
+				Size: image.Pt(2,2),
+			},
+			ExpectedOffsets: []image.Point{
+				{10,20}, {12,20},
+				{10,22}, {12,22},
+			},
+		},
+		{
+			Options: imagerelocate.PatchOptions{
+				Size: image.Pt(3,4),
+				Stride: image.Pt(1,1),
+			},
+			ExpectedOffsets: []image.Point{
+				{10,20}, {11,20}, {12,20},
+			},
+		},
+		{
+			Options: imagerelocate.PatchOptions{
+				Size: image.Pt(3,3),
+				Padding: imagerelocate.PadTransparent,
+			},
+			ExpectedOffsets: []image.Point{
+				{10,20}, {13,20},
+				{10,23}, {13,23},
+			},
+			Check: image.Pt(15,23),
+			ExpectedCheck: color.Transparent,
+		},
+		{
+			Options: imagerelocate.PatchOptions{
+				Size: image.Pt(3,3),
+				Padding: imagerelocate.PadClamp,
+				Normalize: true,
+			},
+			ExpectedOffsets: []image.Point{
+				{10,20}, {13,20},
+				{10,23}, {13,23},
+			},
+			Check: image.Pt(15,23),
+			ExpectedCheck: src.At(14,23),
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		var offsets []image.Point
+		for patch := range imagerelocate.Patches(src, test.Options) {
+			offsets = append(offsets, patch.Offset)
+
+			expectedBounds := image.Rectangle{Min:patch.Offset, Max:patch.Offset.Add(test.Options.Size)}
+			if test.Options.Normalize {
+				expectedBounds = expectedBounds.Sub(patch.Offset)
+			}
+
+			if expected, actual := expectedBounds, patch.Image.Bounds(); expected != actual {
+				t.Errorf("For test #%d, the actual bounds of a patch are not what was expected.", testNumber)
+				t.Logf("EXPECTED: %#v", expected)
+				t.Logf("ACTUAL:   %#v", actual)
+				continue
+			}
+
+			// Check a pixel inside of the source image.
+			p := patch.Offset
+			q := p
+			if test.Options.Normalize {
+				q = q.Sub(patch.Offset)
+			}
+			if expected, actual := src.At(p.X, p.Y), patch.Image.At(q.X, q.Y); expected != actual {
+				t.Errorf("For test #%d, the actual color of a patch is not what was expected.", testNumber)
+				t.Logf("EXPECTED: %#v", expected)
+				t.Logf("ACTUAL:   %#v", actual)
+				continue
+			}
+
+			// Check a pixel past the edge of the source image.
+			if nil != test.ExpectedCheck && test.Check.In(image.Rectangle{Min:patch.Offset, Max:patch.Offset.Add(test.Options.Size)}) {
+				q := test.Check
+				if test.Options.Normalize {
+					q = q.Sub(patch.Offset)
+				}
+
+				if expected, actual := test.ExpectedCheck, patch.Image.At(q.X, q.Y); expected != actual {
+					t.Errorf("For test #%d, the actual padded color of a patch is not what was expected.", testNumber)
+					t.Logf("EXPECTED: %#v", expected)
+					t.Logf("ACTUAL:   %#v", actual)
+					continue
+				}
+			}
+		}
+
+		if expected, actual := test.ExpectedOffsets, offsets; !reflect.DeepEqual(expected, actual) {
+			t.Errorf("For test #%d, the actual offsets are not what was expected.", testNumber)
+			t.Logf("EXPECTED: %#v", expected)
+			t.Logf("ACTUAL:   %#v", actual)
+			continue
+		}
+	}
+}
+
+func TestRandomPatches(t *testing.T) {
+
+	src := image.NewGray(image.Rect(-5,-5, 20,20))
+
+	opts := imagerelocate.PatchOptions{
+		Size: image.Pt(8,6),
+	}
+
+	var first []image.Point
+	for patch := range imagerelocate.RandomPatches(src, opts, 50, rand.NewSource(7)) {
+		if !patch.Image.Bounds().In(src.Rect) {
+			t.Errorf("A random patch is not inside of the image.")
+			t.Logf("BOUNDS: %#v", patch.Image.Bounds())
+		}
+		first = append(first, patch.Offset)
+	}
+
+	var second []image.Point
+	for patch := range imagerelocate.RandomPatches(src, opts, 50, rand.NewSource(7)) {
+		second = append(second, patch.Offset)
+	}
+
+	if expected, actual := 50, len(first); expected != actual {
+		t.Errorf("The actual number of random patches is not what was expected.")
+		t.Logf("EXPECTED: %d", expected)
+		t.Logf("ACTUAL:   %d", actual)
+	}
+
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("The same seed did not result in the same random patches.")
+	}
+}
@@ -0,0 +1,369 @@
+package imagerelocate
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"strings"
+)
+
+type pipelineOpKind int
+
+const (
+	pipelineRelocate pipelineOpKind = iota
+	pipelineCrop
+	pipelineFlipX
+	pipelineFlipY
+	pipelinePad
+)
+
+type pipelineOp struct {
+	kind   pipelineOpKind
+	offset image.Point
+	rect   image.Rectangle
+	insets Insets
+	fill   color.Color
+}
+
+func (receiver pipelineOp) String() string {
+	switch receiver.kind {
+	case pipelineRelocate:
+		return fmt.Sprintf("relocate(%d,%d)", receiver.offset.X, receiver.offset.Y)
+	case pipelineCrop:
+		return fmt.Sprintf("crop(%v)", receiver.rect)
+	case pipelineFlipX:
+		return "flipX"
+	case pipelineFlipY:
+		return "flipY"
+	case pipelinePad:
+		return fmt.Sprintf("pad(%d,%d,%d,%d)", receiver.insets.Top, receiver.insets.Right, receiver.insets.Bottom, receiver.insets.Left)
+	default:
+		return "?"
+	}
+}
+
+// Pipeline records a chain of lazy operations (relocate, crop, flip, and pad) on an image, and simplifies them —
+// so that rather than each operation adding its own per-pixel overhead, the whole chain becomes a single coordinate mapping.
+//
+// For example:
+//
+//	img := imagerelocate.NewPipeline(sprite).Relocate(10,0).Relocate(5,5).FlipX().Crop(r).FlipX().Image()
+//
+// Consecutive relocations are merged, double flips cancel, and crops are folded into one rectangle.
+// When no flips are left, the concrete image types in Go's built-in "image" package (such as *image.RGBA)
+// compile to a sub-image with a rewritten .Rect — which keeps draw.Draw's fast paths.
+//
+// Flips mirror the image within its bounds (at that point in the chain).
+// Pads cannot be folded into a coordinate mapping, so each pad starts a new mapping.
+//
+// Pipelines are values; each method returns a new Pipeline, and does not change the one it is called on.
+type Pipeline struct {
+	source image.Image
+	ops    []pipelineOp
+}
+
+// NewPipeline returns a Pipeline (with no operations yet) on ‘img’.
+func NewPipeline(img image.Image) Pipeline {
+	return Pipeline{
+		source: img,
+	}
+}
+
+func (receiver Pipeline) with(op pipelineOp) Pipeline {
+	ops := make([]pipelineOp, len(receiver.ops), len(receiver.ops)+1)
+	copy(ops, receiver.ops)
+
+	return Pipeline{
+		source: receiver.source,
+		ops:    append(ops, op),
+	}
+}
+
+// Relocate adds relocating by (‘dx’,‘dy’) to the chain. (See Wrap.)
+func (receiver Pipeline) Relocate(dx, dy int) Pipeline {
+	return receiver.with(pipelineOp{kind:pipelineRelocate, offset:image.Point{dx,dy}})
+}
+
+// Crop adds cropping to ‘r’ (keeping coordinates) to the chain.
+func (receiver Pipeline) Crop(r image.Rectangle) Pipeline {
+	return receiver.with(pipelineOp{kind:pipelineCrop, rect:r.Canon()})
+}
+
+// FlipX adds mirroring horizontally (within the current bounds) to the chain.
+func (receiver Pipeline) FlipX() Pipeline {
+	return receiver.with(pipelineOp{kind:pipelineFlipX})
+}
+
+// FlipY adds mirroring vertically (within the current bounds) to the chain.
+func (receiver Pipeline) FlipY() Pipeline {
+	return receiver.with(pipelineOp{kind:pipelineFlipY})
+}
+
+// Pad adds padding by ‘insets’ with ‘fill’ to the chain. (See Pad.)
+func (receiver Pipeline) Pad(insets Insets, fill color.Color) Pipeline {
+	return receiver.with(pipelineOp{kind:pipelinePad, insets:insets, fill:fill})
+}
+
+// Simplify returns the equivalent Pipeline with its chain simplified.
+func (receiver Pipeline) Simplify() Pipeline {
+	simplified := Pipeline{
+		source: receiver.source,
+	}
+
+	receiver.compile(func(mapping pipelineMapping, pad *pipelineOp) {
+		simplified.ops = append(simplified.ops, mapping.ops()...)
+		if nil != pad {
+			simplified.ops = append(simplified.ops, *pad)
+		}
+	})
+
+	return simplified
+}
+
+// String returns the simplified chain. For example:
+//
+//	source → flipX → relocate(15,5) → crop((15,5)-(19,8))
+func (receiver Pipeline) String() string {
+	var buffer strings.Builder
+
+	buffer.WriteString("source")
+	for _, op := range receiver.Simplify().ops {
+		buffer.WriteString(" → ")
+		buffer.WriteString(op.String())
+	}
+
+	return buffer.String()
+}
+
+// Image returns the image that results from the (simplified) chain.
+func (receiver Pipeline) Image() image.Image {
+	var img image.Image
+
+	receiver.compile(func(mapping pipelineMapping, pad *pipelineOp) {
+		img = mapping.image()
+		if nil != pad {
+			img = Pad(img, pad.insets, pad.fill)
+		}
+	})
+
+	return img
+}
+
+// compile compiles each run of operations between pads into a single mapping, and calls ‘fn’ with each mapping,
+// and the pad that follows it (if any).
+func (receiver Pipeline) compile(fn func(mapping pipelineMapping, pad *pipelineOp)) {
+	var img image.Image = receiver.source
+	mapping := newPipelineMapping(img)
+
+	for i, op := range receiver.ops {
+		if pipelinePad != op.kind {
+			mapping.apply(op)
+			continue
+		}
+
+		pad := receiver.ops[i]
+		fn(mapping, &pad)
+
+		img = Pad(mapping.image(), pad.insets, pad.fill)
+		mapping = newPipelineMapping(img)
+	}
+
+	fn(mapping, nil)
+}
+
+// pipelineMapping is a run of relocate, crop, and flip operations compiled into a single coordinate mapping:
+//
+//	x_source = flipX ? (sourceMin.X + sourceMax.X - 1) - (x - offset.X) : x - offset.X
+//
+// (and the same for y), only within ‘bounds’.
+type pipelineMapping struct {
+	source       image.Image
+	sourceBounds image.Rectangle
+	flipX, flipY bool
+	offset       image.Point
+	bounds       image.Rectangle
+}
+
+func newPipelineMapping(img image.Image) pipelineMapping {
+	bounds := img.Bounds()
+
+	return pipelineMapping{
+		source:       img,
+		sourceBounds: bounds,
+		bounds:       bounds,
+	}
+}
+
+// mapped returns the bounds of the source, with the mapping applied, but without any cropping.
+func (receiver pipelineMapping) mapped() image.Rectangle {
+	return translate(receiver.sourceBounds, receiver.offset.X, receiver.offset.Y)
+}
+
+func (receiver *pipelineMapping) apply(op pipelineOp) {
+	switch op.kind {
+	case pipelineRelocate:
+		receiver.offset = receiver.offset.Add(op.offset)
+		receiver.bounds = translate(receiver.bounds, op.offset.X, op.offset.Y)
+
+	case pipelineCrop:
+		receiver.bounds = receiver.bounds.Intersect(op.rect)
+
+	case pipelineFlipX:
+		// Mirroring around the current bounds is the same as mirroring around the source bounds, and then relocating.
+		k := receiver.bounds.Min.X + receiver.bounds.Max.X - 1
+		s := receiver.sourceBounds.Min.X + receiver.sourceBounds.Max.X - 1
+
+		receiver.flipX = !receiver.flipX
+		receiver.offset.X = k - s - receiver.offset.X
+		receiver.bounds.Min.X, receiver.bounds.Max.X = k+1-receiver.bounds.Max.X, k+1-receiver.bounds.Min.X
+
+	case pipelineFlipY:
+		k := receiver.bounds.Min.Y + receiver.bounds.Max.Y - 1
+		s := receiver.sourceBounds.Min.Y + receiver.sourceBounds.Max.Y - 1
+
+		receiver.flipY = !receiver.flipY
+		receiver.offset.Y = k - s - receiver.offset.Y
+		receiver.bounds.Min.Y, receiver.bounds.Max.Y = k+1-receiver.bounds.Max.Y, k+1-receiver.bounds.Min.Y
+	}
+
+	if receiver.bounds.Empty() {
+		receiver.bounds = image.Rectangle{}
+	}
+}
+
+// ops returns the simplest chain of operations for the mapping: flips, then a relocation, then a crop.
+func (receiver pipelineMapping) ops() []pipelineOp {
+	var ops []pipelineOp
+
+	if receiver.flipX {
+		ops = append(ops, pipelineOp{kind:pipelineFlipX})
+	}
+	if receiver.flipY {
+		ops = append(ops, pipelineOp{kind:pipelineFlipY})
+	}
+	if (image.Point{}) != receiver.offset {
+		ops = append(ops, pipelineOp{kind:pipelineRelocate, offset:receiver.offset})
+	}
+	if receiver.bounds != receiver.mapped() {
+		ops = append(ops, pipelineOp{kind:pipelineCrop, rect:receiver.bounds})
+	}
+
+	return ops
+}
+
+// image returns the mapping as an image.
+func (receiver pipelineMapping) image() image.Image {
+	src := receiver.source
+	cropped := receiver.bounds != receiver.mapped()
+
+	if receiver.bounds.Empty() {
+		return internalCroppedImage{
+			img: src,
+		}
+	}
+
+	if !receiver.flipX && !receiver.flipY {
+		if !cropped {
+			if (image.Point{}) == receiver.offset {
+				return src
+			}
+			if rebased, ok := rebase(src, receiver.offset); ok {
+				return rebased
+			}
+			return Wrap(receiver.offset.X, receiver.offset.Y, src)
+		}
+
+		sub := crop(src, receiver.bounds.Sub(receiver.offset))
+		if rebased, ok := rebase(sub, receiver.offset); ok {
+			return rebased
+		}
+		return Wrap(receiver.offset.X, receiver.offset.Y, sub)
+	}
+
+	return internalMappedImage{
+		mapping: receiver,
+	}
+}
+
+// rebase relocates the concrete image types in Go's built-in "image" package by rewriting their .Rect (and sharing their .Pix).
+//
+// (*image.YCbCr and *image.NYCbCrA are handled by Wrap, since their chroma subsampling has to be taken into account.)
+func rebase(img image.Image, offset image.Point) (image.Image, bool) {
+	switch casted := img.(type) {
+	case *image.RGBA:
+		rebased := *casted
+		rebased.Rect = casted.Rect.Add(offset)
+		return &rebased, true
+	case *image.NRGBA:
+		rebased := *casted
+		rebased.Rect = casted.Rect.Add(offset)
+		return &rebased, true
+	case *image.RGBA64:
+		rebased := *casted
+		rebased.Rect = casted.Rect.Add(offset)
+		return &rebased, true
+	case *image.NRGBA64:
+		rebased := *casted
+		rebased.Rect = casted.Rect.Add(offset)
+		return &rebased, true
+	case *image.Gray:
+		rebased := *casted
+		rebased.Rect = casted.Rect.Add(offset)
+		return &rebased, true
+	case *image.Gray16:
+		rebased := *casted
+		rebased.Rect = casted.Rect.Add(offset)
+		return &rebased, true
+	case *image.Alpha:
+		rebased := *casted
+		rebased.Rect = casted.Rect.Add(offset)
+		return &rebased, true
+	case *image.Alpha16:
+		rebased := *casted
+		rebased.Rect = casted.Rect.Add(offset)
+		return &rebased, true
+	case *image.CMYK:
+		rebased := *casted
+		rebased.Rect = casted.Rect.Add(offset)
+		return &rebased, true
+	case *image.Paletted:
+		rebased := *casted
+		rebased.Rect = casted.Rect.Add(offset)
+		return &rebased, true
+	default:
+		return nil, false
+	}
+}
+
+// internalMappedImage is a compiled pipelineMapping (that has flips).
+type internalMappedImage struct {
+	mapping pipelineMapping
+}
+
+func (receiver internalMappedImage) At(x, y int) color.Color {
+	mapping := receiver.mapping
+
+	if !(image.Point{x,y}).In(mapping.bounds) {
+		return color.Transparent
+	}
+
+	x -= mapping.offset.X
+	y -= mapping.offset.Y
+
+	if mapping.flipX {
+		x = mapping.sourceBounds.Min.X + mapping.sourceBounds.Max.X-1 - x
+	}
+	if mapping.flipY {
+		y = mapping.sourceBounds.Min.Y + mapping.sourceBounds.Max.Y-1 - y
+	}
+
+	return mapping.source.At(x,y)
+}
+
+func (receiver internalMappedImage) Bounds() image.Rectangle {
+	return receiver.mapping.bounds
+}
+
+func (receiver internalMappedImage) ColorModel() color.Model {
+	return receiver.mapping.source.ColorModel()
+}
@@ -0,0 +1,203 @@
+package imagerelocate_test
+
+import (
+	"github.com/reiver/go-imagerelocate"
+
+	"image"
+	"image/color"
+	"math/rand"
+
+	"testing"
+)
+
+func TestPipeline_String(t *testing.T) {
+
+	src := image.NewRGBA(image.Rect(0,0, 4,3))
+
+	tests := []struct{
+		Pipeline imagerelocate.Pipeline
+		Expected string
+	}{
+		{
+			Pipeline: imagerelocate.NewPipeline(src),
+			Expected: "source",
+		},
+		{
+			Pipeline: imagerelocate.NewPipeline(src).Relocate(10,0).Relocate(5,5),
+			Expected: "source → relocate(15,5)",
+		},
+		{
+			Pipeline: imagerelocate.NewPipeline(src).FlipX().FlipY().FlipX().FlipY(),
+			Expected: "source",
+		},
+		{
+			Pipeline: imagerelocate.NewPipeline(src).Relocate(-3,2).Relocate(3,-2),
+			Expected: "source",
+		},
+		{
+			Pipeline: imagerelocate.NewPipeline(src).Crop(image.Rect(1,0, 10,10)).Crop(image.Rect(-5,-5, 3,2)),
+			Expected: "source → crop((1,0)-(3,2))",
+		},
+		{
+			Pipeline: imagerelocate.NewPipeline(src).Crop(image.Rect(-10,-10, 10,10)),
+			Expected: "source",
+		},
+		{
+			Pipeline: imagerelocate.NewPipeline(src).Relocate(1,1).Pad(imagerelocate.UniformInsets(1), nil).Relocate(2,2).Relocate(-1,0),
+			Expected: "source → relocate(1,1) → pad(1,1,1,1) → relocate(1,2)",
+		},
+		{
+			Pipeline: imagerelocate.NewPipeline(src).FlipX(),
+			Expected: "source → flipX",
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		if expected, actual := test.Expected, test.Pipeline.String(); expected != actual {
+			t.Errorf("For test #%d, the actual string is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %q", expected)
+			t.Logf("ACTUAL:   %q", actual)
+			continue
+		}
+	}
+}
+
+func TestPipeline_rebase(t *testing.T) {
+
+	src := image.NewRGBA(image.Rect(0,0, 4,3))
+	src.Set(2,1, color.RGBA{R:255, A:255})
+
+	img := imagerelocate.NewPipeline(src).Relocate(7,7).Crop(image.Rect(8,8, 10,10)).FlipX().FlipX().Image()
+
+	rgba, casted := img.(*image.RGBA)
+	if !casted {
+		t.Errorf("Expected the image to be an *image.RGBA, but it wasn't.")
+		t.Logf("TYPE: %T", img)
+		return
+	}
+
+	if expected, actual := image.Rect(8,8, 10,10), rgba.Rect; expected != actual {
+		t.Errorf("The actual bounds are not what was expected.")
+		t.Logf("EXPECTED: %#v", expected)
+		t.Logf("ACTUAL:   %#v", actual)
+	}
+
+	if expected, actual := src.At(2,1), rgba.At(9,8); expected != actual {
+		t.Errorf("The actual color is not what was expected.")
+		t.Logf("EXPECTED: %#v", expected)
+		t.Logf("ACTUAL:   %#v", actual)
+	}
+}
+
+// naiveImage applies each operation one at a time, as its own layer — which is what Pipeline should be equivalent to.
+type naiveImage struct {
+	bounds image.Rectangle
+	at func(x, y int) color.Color
+}
+
+func (receiver naiveImage) At(x, y int) color.Color {
+	if !(image.Point{x,y}).In(receiver.bounds) {
+		return color.Transparent
+	}
+	return receiver.at(x,y)
+}
+
+func (receiver naiveImage) Bounds() image.Rectangle {
+	return receiver.bounds
+}
+
+func (receiver naiveImage) ColorModel() color.Model {
+	return color.RGBA64Model
+}
+
+func TestPipeline_Image(t *testing.T) {
+
+	randomness := rand.New(rand.NewSource(1))
+
+	src := image.NewRGBA(image.Rect(-2,3, 5,8))
+	for i := range src.Pix {
+		src.Pix[i] = uint8(randomness.Intn(256))
+	}
+
+	fill := color.RGBA64{B:0xffff, A:0xffff}
+
+	for testNumber:=0; testNumber<300; testNumber++ {
+
+		pipeline := imagerelocate.NewPipeline(src)
+		var naive image.Image = naiveImage{bounds:src.Rect, at:src.At}
+
+		for i:=randomness.Intn(8); 0<i; i-- {
+			previous := naive
+			bounds := previous.Bounds()
+
+			switch randomness.Intn(5) {
+			case 0:
+				dx, dy := randomness.Intn(9)-4, randomness.Intn(9)-4
+				pipeline = pipeline.Relocate(dx,dy)
+				naive = naiveImage{bounds:bounds.Add(image.Pt(dx,dy)), at:func(x, y int) color.Color {
+					return previous.At(x-dx, y-dy)
+				}}
+			case 1:
+				x, y := randomness.Intn(12)-6, randomness.Intn(12)
+				r := image.Rect(x,y, x+randomness.Intn(8), y+randomness.Intn(8))
+				pipeline = pipeline.Crop(r)
+				naive = naiveImage{bounds:bounds.Intersect(r), at:previous.At}
+			case 2:
+				pipeline = pipeline.FlipX()
+				naive = naiveImage{bounds:bounds, at:func(x, y int) color.Color {
+					return previous.At(bounds.Min.X+bounds.Max.X-1-x, y)
+				}}
+			case 3:
+				pipeline = pipeline.FlipY()
+				naive = naiveImage{bounds:bounds, at:func(x, y int) color.Color {
+					return previous.At(x, bounds.Min.Y+bounds.Max.Y-1-y)
+				}}
+			case 4:
+				insets := imagerelocate.Insets{Top:randomness.Intn(3), Right:randomness.Intn(3), Bottom:randomness.Intn(3), Left:randomness.Intn(3)}
+				pipeline = pipeline.Pad(insets, fill)
+				naive = naiveImage{bounds:insets.Grow(bounds), at:func(x, y int) color.Color {
+					if !(image.Point{x,y}).In(bounds) {
+						return fill
+					}
+					return previous.At(x,y)
+				}}
+			}
+
+			if naive.Bounds().Empty() {
+				naive = naiveImage{}
+			}
+		}
+
+		img := pipeline.Image()
+
+		expectedBounds := naive.Bounds()
+		actualBounds := img.Bounds()
+		if expectedBounds.Empty() && actualBounds.Empty() {
+			continue
+		}
+
+		if expected, actual := expectedBounds, actualBounds; expected != actual {
+			t.Errorf("For test #%d, the actual bounds are not what was expected.", testNumber)
+			t.Logf("PIPELINE: %s", pipeline)
+			t.Logf("EXPECTED: %#v", expected)
+			t.Logf("ACTUAL:   %#v", actual)
+			continue
+		}
+
+		for y:=expectedBounds.Min.Y; y<expectedBounds.Max.Y; y++ {
+			for x:=expectedBounds.Min.X; x<expectedBounds.Max.X; x++ {
+				expected := color.RGBA64Model.Convert(naive.At(x,y))
+				actual   := color.RGBA64Model.Convert(img.At(x,y))
+
+				if expected != actual {
+					t.Errorf("For test #%d, the actual color at (%d,%d) is not what was expected.", testNumber, x,y)
+					t.Logf("PIPELINE: %s", pipeline)
+					t.Logf("EXPECTED: %#v", expected)
+					t.Logf("ACTUAL:   %#v", actual)
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package imagerelocate
+
+import (
+	"image"
+	"iter"
+)
+
+// Region is a set of pixels, stored as disjoint rectangles — for example, the union of the bounds of many relocated images.
+//
+// Unlike image.Rectangle.Union, which returns a bounding box, a Region is exact.
+//
+// Regions are values; the methods of Region return a new Region, and do not change the one they are called on.
+// The zero value is an empty Region.
+type Region struct {
+	rects []image.Rectangle
+}
+
+// NewRegion returns the Region that is the union of ‘rects’.
+func NewRegion(rects ...image.Rectangle) Region {
+	var region Region
+
+	for _, r := range rects {
+		region = region.UnionRect(r)
+	}
+
+	return region
+}
+
+// Rectangles returns an iterator over the disjoint rectangles that make up the Region.
+func (receiver Region) Rectangles() iter.Seq[image.Rectangle] {
+	return func(yield func(image.Rectangle) bool) {
+		for _, r := range receiver.rects {
+			if !yield(r) {
+				return
+			}
+		}
+	}
+}
+
+// Len returns how many disjoint rectangles make up the Region.
+func (receiver Region) Len() int {
+	return len(receiver.rects)
+}
+
+// Empty returns whether the Region has no pixels.
+func (receiver Region) Empty() bool {
+	return len(receiver.rects) <= 0
+}
+
+// Area returns how many pixels are in the Region.
+func (receiver Region) Area() int {
+	var sum int
+
+	for _, r := range receiver.rects {
+		sum += area(r)
+	}
+
+	return sum
+}
+
+// Bounds returns the bounding box of the Region.
+func (receiver Region) Bounds() image.Rectangle {
+	var bounds image.Rectangle
+
+	for _, r := range receiver.rects {
+		bounds = bounds.Union(r)
+	}
+
+	return bounds
+}
+
+// Contains returns whether ‘p’ is in the Region.
+func (receiver Region) Contains(p image.Point) bool {
+	for _, r := range receiver.rects {
+		if p.In(r) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Translate returns the Region moved by (‘dx’,‘dy’) — just like relocating an image with Wrap moves its bounds.
+func (receiver Region) Translate(dx, dy int) Region {
+	rects := make([]image.Rectangle, len(receiver.rects))
+
+	for i, r := range receiver.rects {
+		rects[i] = r.Add(image.Point{dx,dy})
+	}
+
+	return Region{rects:rects}
+}
+
+// Union returns the pixels that are in either Region.
+func (receiver Region) Union(other Region) Region {
+	result := receiver
+	for _, r := range other.rects {
+		result = result.UnionRect(r)
+	}
+
+	return result
+}
+
+// UnionRect returns the pixels that are in the Region or in ‘r’.
+func (receiver Region) UnionRect(r image.Rectangle) Region {
+	pieces := subtractRects([]image.Rectangle{r.Canon()}, receiver.rects)
+
+	rects := append(append([]image.Rectangle(nil), receiver.rects...), pieces...)
+
+	return Region{rects:coalesceRects(rects)}
+}
+
+// Intersect returns the pixels that are in both Regions.
+func (receiver Region) Intersect(other Region) Region {
+	var rects []image.Rectangle
+
+	for _, a := range receiver.rects {
+		for _, b := range other.rects {
+			if intersection := a.Intersect(b); !intersection.Empty() {
+				rects = append(rects, intersection)
+			}
+		}
+	}
+
+	return Region{rects:coalesceRects(rects)}
+}
+
+// IntersectRect returns the pixels that are in both the Region and ‘r’ — i.e., the Region clipped to ‘r’.
+func (receiver Region) IntersectRect(r image.Rectangle) Region {
+	return receiver.Intersect(Region{rects:[]image.Rectangle{r.Canon()}})
+}
+
+// Subtract returns the pixels that are in the Region, but not in ‘other’.
+func (receiver Region) Subtract(other Region) Region {
+	return Region{rects:coalesceRects(subtractRects(receiver.rects, other.rects))}
+}
+
+// SubtractRect returns the pixels that are in the Region, but not in ‘r’.
+func (receiver Region) SubtractRect(r image.Rectangle) Region {
+	return receiver.Subtract(Region{rects:[]image.Rectangle{r.Canon()}})
+}
+
+// subtractRects returns the parts of ‘rects’ that are not in any of ‘holes’, as disjoint rectangles.
+//
+// ‘rects’ must be disjoint.
+func subtractRects(rects []image.Rectangle, holes []image.Rectangle) []image.Rectangle {
+	var result []image.Rectangle
+	for _, r := range rects {
+		if !r.Empty() {
+			result = append(result, r)
+		}
+	}
+
+	for _, hole := range holes {
+		var next []image.Rectangle
+
+		for _, r := range result {
+			next = append(next, subtractRect(r, hole)...)
+		}
+
+		result = next
+	}
+
+	return result
+}
+
+// subtractRect returns the (up to 4) disjoint rectangles that make up the part of ‘r’ that is not in ‘hole’.
+func subtractRect(r, hole image.Rectangle) []image.Rectangle {
+	overlap := r.Intersect(hole)
+	if overlap.Empty() {
+		return []image.Rectangle{r}
+	}
+
+	var pieces []image.Rectangle
+
+	// Above and below take the whole width; left and right only take the height of the overlap.
+	if r.Min.Y < overlap.Min.Y {
+		pieces = append(pieces, image.Rect(r.Min.X, r.Min.Y, r.Max.X, overlap.Min.Y))
+	}
+	if overlap.Max.Y < r.Max.Y {
+		pieces = append(pieces, image.Rect(r.Min.X, overlap.Max.Y, r.Max.X, r.Max.Y))
+	}
+	if r.Min.X < overlap.Min.X {
+		pieces = append(pieces, image.Rect(r.Min.X, overlap.Min.Y, overlap.Min.X, overlap.Max.Y))
+	}
+	if overlap.Max.X < r.Max.X {
+		pieces = append(pieces, image.Rect(overlap.Max.X, overlap.Min.Y, r.Max.X, overlap.Max.Y))
+	}
+
+	return pieces
+}
+
+// coalesceRects merges disjoint rectangles that share an entire edge, so that a Region does not have more rectangles than it needs.
+func coalesceRects(rects []image.Rectangle) []image.Rectangle {
+	for merged := true; merged; {
+		merged = false
+
+		for i:=0; i<len(rects); i++ {
+			for j:=i+1; j<len(rects); j++ {
+				a, b := rects[i], rects[j]
+
+				sideBySide := a.Min.Y == b.Min.Y && a.Max.Y == b.Max.Y && (a.Max.X == b.Min.X || b.Max.X == a.Min.X)
+				stacked := a.Min.X == b.Min.X && a.Max.X == b.Max.X && (a.Max.Y == b.Min.Y || b.Max.Y == a.Min.Y)
+
+				if !sideBySide && !stacked {
+					continue
+				}
+
+				rects[i] = a.Union(b)
+				rects = append(rects[:j], rects[j+1:]...)
+				merged = true
+				j = i
+			}
+		}
+	}
+
+	return rects
+}
@@ -0,0 +1,104 @@
+package imagerelocate_test
+
+import (
+	"github.com/reiver/go-imagerelocate"
+
+	"image"
+	"math/rand"
+
+	"testing"
+)
+
+func TestRegion(t *testing.T) {
+
+	randomness := rand.New(rand.NewSource(1))
+
+	randomRect := func() image.Rectangle {
+		x, y := randomness.Intn(40)-20, randomness.Intn(40)-20
+		return image.Rect(x,y, x+randomness.Intn(20), y+randomness.Intn(20))
+	}
+
+	randomRegion := func() (imagerelocate.Region, []image.Rectangle) {
+		var rects []image.Rectangle
+		for i:=randomness.Intn(6); 0<i; i-- {
+			rects = append(rects, randomRect())
+		}
+		return imagerelocate.NewRegion(rects...), rects
+	}
+
+	inAny := func(p image.Point, rects []image.Rectangle) bool {
+		for _, r := range rects {
+			if p.In(r) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for testNumber:=0; testNumber<200; testNumber++ {
+
+		a, aRects := randomRegion()
+		b, bRects := randomRegion()
+
+		union := a.Union(b)
+		intersection := a.Intersect(b)
+		difference := a.Subtract(b)
+		translated := a.Translate(3,-4)
+
+		for _, region := range []imagerelocate.Region{a, b, union, intersection, difference, translated} {
+			var rects []image.Rectangle
+			for r := range region.Rectangles() {
+				for _, other := range rects {
+					if r.Overlaps(other) {
+						t.Errorf("For test #%d, the rectangles of a region are not disjoint.", testNumber)
+						t.Logf("RECTANGLE: %#v", r)
+						t.Logf("RECTANGLE: %#v", other)
+					}
+				}
+				rects = append(rects, r)
+			}
+		}
+
+		var area int
+		for y:=-45; y<45; y++ {
+			for x:=-45; x<45; x++ {
+				p := image.Pt(x,y)
+
+				inA := inAny(p, aRects)
+				inB := inAny(p, bRects)
+
+				if inA {
+					area++
+				}
+
+				if expected, actual := inA, a.Contains(p); expected != actual {
+					t.Errorf("For test #%d, whether a contains (%d,%d) is not what was expected.", testNumber, x,y)
+					return
+				}
+				if expected, actual := inA || inB, union.Contains(p); expected != actual {
+					t.Errorf("For test #%d, whether the union contains (%d,%d) is not what was expected.", testNumber, x,y)
+					return
+				}
+				if expected, actual := inA && inB, intersection.Contains(p); expected != actual {
+					t.Errorf("For test #%d, whether the intersection contains (%d,%d) is not what was expected.", testNumber, x,y)
+					return
+				}
+				if expected, actual := inA && !inB, difference.Contains(p); expected != actual {
+					t.Errorf("For test #%d, whether the difference contains (%d,%d) is not what was expected.", testNumber, x,y)
+					return
+				}
+				if expected, actual := inA, translated.Contains(p.Add(image.Pt(3,-4))); expected != actual {
+					t.Errorf("For test #%d, whether the translated region contains (%d,%d) is not what was expected.", testNumber, x+3,y-4)
+					return
+				}
+			}
+		}
+
+		if expected, actual := area, a.Area(); expected != actual {
+			t.Errorf("For test #%d, the actual area is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %d", expected)
+			t.Logf("ACTUAL:   %d", actual)
+			continue
+		}
+	}
+}
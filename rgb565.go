@@ -0,0 +1,37 @@
+package imagerelocate
+
+import (
+	"image/color"
+)
+
+// RGB565 is a 16-bit opaque pixel, with 5 bits of red, 6 bits of green, and 5 bits of blue.
+//
+// It can be used as the pixel type of a Buffer:
+//
+//	var img *imagerelocate.Buffer[imagerelocate.RGB565] = imagerelocate.NewBuffer[imagerelocate.RGB565](image.Rect(0,0, 320,240))
+type RGB565 uint16
+
+var _ Pixel[RGB565] = RGB565(0)
+
+// RGBA64 returns the (opaque) color of the pixel.
+func (receiver RGB565) RGBA64() color.RGBA64 {
+	r := uint32(receiver>>11) & 0x1f
+	g := uint32(receiver>>5) & 0x3f
+	b := uint32(receiver) & 0x1f
+
+	return color.RGBA64{
+		R: uint16(r * 0xffff / 0x1f),
+		G: uint16(g * 0xffff / 0x3f),
+		B: uint16(b * 0xffff / 0x1f),
+		A: 0xffff,
+	}
+}
+
+// FromRGBA64 returns the RGB565 pixel closest to ‘c’ (composited over black, since RGB565 has no alpha).
+func (RGB565) FromRGBA64(c color.RGBA64) RGB565 {
+	r := (uint32(c.R)*0x1f + 0x7fff) / 0xffff
+	g := (uint32(c.G)*0x3f + 0x7fff) / 0xffff
+	b := (uint32(c.B)*0x1f + 0x7fff) / 0xffff
+
+	return RGB565(r<<11 | g<<5 | b)
+}
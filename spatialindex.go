@@ -0,0 +1,364 @@
+package imagerelocate
+
+import (
+	"image"
+	"image/color"
+	"sort"
+)
+
+// DefaultCellSize is the cell size used by a SpatialIndex when none (or a non-positive one) was given.
+const DefaultCellSize = 64
+
+// SpatialIndex is a uniform-grid spatial index over many (relocated) images, keyed by their bounds.
+//
+// It makes "which images cover this point" and "which images intersect this rectangle" fast, even with thousands of images.
+//
+// Images are identified by the ID returned from .Insert().
+// IDs are handed out in increasing order, and results are always returned in ID order —
+// which is also the order images are composited in by .Image() (i.e., later inserted images are on top).
+//
+// Unbounded images (see IsUnbounded) cover everything, and so are not put into the grid.
+//
+// A SpatialIndex is not safe to use from multiple goroutines at the same time.
+type SpatialIndex struct {
+	cellSize  int
+	nextID    int
+	entries   map[int]spatialEntry
+	cells     map[image.Point][]int
+	unbounded []int
+	union     image.Rectangle
+}
+
+type spatialEntry struct {
+	img    image.Image
+	bounds image.Rectangle
+}
+
+// NewSpatialIndex returns a new (empty) SpatialIndex whose grid cells are ‘cellSize’×‘cellSize’ pixels.
+//
+// A good ‘cellSize’ is about the size of a typical image in the index.
+func NewSpatialIndex(cellSize int) *SpatialIndex {
+	if cellSize <= 0 {
+		cellSize = DefaultCellSize
+	}
+
+	return &SpatialIndex{
+		cellSize: cellSize,
+		entries:  map[int]spatialEntry{},
+		cells:    map[image.Point][]int{},
+	}
+}
+
+// Insert adds ‘img’ to the index, and returns its ID.
+func (receiver *SpatialIndex) Insert(img image.Image) int {
+	id := receiver.nextID
+	receiver.nextID++
+
+	receiver.add(id, img)
+
+	return id
+}
+
+// Update replaces the image with ID ‘id’ with ‘img’ — for example, when a sprite is moved by wrapping it with a new offset.
+//
+// Only the grid cells that the image leaves, or enters, are touched.
+//
+// Update returns false if there is no image with ID ‘id’.
+func (receiver *SpatialIndex) Update(id int, img image.Image) bool {
+	entry, found := receiver.entries[id]
+	if !found {
+		return false
+	}
+
+	if IsUnbounded(entry.img) || IsUnbounded(img) {
+		receiver.Remove(id)
+		receiver.add(id, img)
+		return true
+	}
+
+	defer receiver.shrinkUnion(entry.bounds)
+
+	oldCells := receiver.cellRange(entry.bounds)
+	newBounds := img.Bounds()
+	newCells := receiver.cellRange(newBounds)
+
+	for cy:=oldCells.Min.Y; cy<oldCells.Max.Y; cy++ {
+		for cx:=oldCells.Min.X; cx<oldCells.Max.X; cx++ {
+			if cell := (image.Point{cx,cy}); !cell.In(newCells) {
+				receiver.removeFromCell(cell, id)
+			}
+		}
+	}
+	for cy:=newCells.Min.Y; cy<newCells.Max.Y; cy++ {
+		for cx:=newCells.Min.X; cx<newCells.Max.X; cx++ {
+			if cell := (image.Point{cx,cy}); !cell.In(oldCells) {
+				receiver.addToCell(cell, id)
+			}
+		}
+	}
+
+	receiver.entries[id] = spatialEntry{
+		img:    img,
+		bounds: newBounds,
+	}
+	receiver.union = receiver.union.Union(newBounds)
+
+	return true
+}
+
+// Remove removes the image with ID ‘id’ from the index.
+//
+// Remove returns false if there is no image with ID ‘id’.
+func (receiver *SpatialIndex) Remove(id int) bool {
+	entry, found := receiver.entries[id]
+	if !found {
+		return false
+	}
+
+	delete(receiver.entries, id)
+	defer receiver.shrinkUnion(entry.img.Bounds())
+
+	if IsUnbounded(entry.img) {
+		receiver.unbounded = removeID(receiver.unbounded, id)
+		return true
+	}
+
+	cells := receiver.cellRange(entry.bounds)
+	for cy:=cells.Min.Y; cy<cells.Max.Y; cy++ {
+		for cx:=cells.Min.X; cx<cells.Max.X; cx++ {
+			receiver.removeFromCell(image.Point{cx,cy}, id)
+		}
+	}
+
+	return true
+}
+
+// Get returns the image with ID ‘id’.
+func (receiver *SpatialIndex) Get(id int) (image.Image, bool) {
+	entry, found := receiver.entries[id]
+
+	return entry.img, found
+}
+
+// Len returns how many images are in the index.
+func (receiver *SpatialIndex) Len() int {
+	return len(receiver.entries)
+}
+
+// CoveringPoint returns the IDs (in ID order) of the images whose bounds contain ‘p’.
+func (receiver *SpatialIndex) CoveringPoint(p image.Point) []int {
+	var ids []int
+
+	receiver.covering(p, func(id int, _ spatialEntry) {
+		ids = append(ids, id)
+	})
+
+	return ids
+}
+
+// covering calls ‘fn’ for each image whose bounds contain ‘p’, in ID order, without allocating.
+//
+// This relies on the unbounded IDs, and the IDs in each cell, being kept sorted.
+func (receiver *SpatialIndex) covering(p image.Point, fn func(id int, entry spatialEntry)) {
+	unbounded := receiver.unbounded
+	cell := receiver.cells[receiver.cellOf(p)]
+
+	for 0 < len(unbounded) || 0 < len(cell) {
+		if 0 < len(unbounded) && (len(cell) <= 0 || unbounded[0] < cell[0]) {
+			id := unbounded[0]
+			unbounded = unbounded[1:]
+
+			fn(id, receiver.entries[id])
+			continue
+		}
+
+		id := cell[0]
+		cell = cell[1:]
+
+		if entry := receiver.entries[id]; p.In(entry.bounds) {
+			fn(id, entry)
+		}
+	}
+}
+
+// Intersecting returns the IDs (in ID order) of the images whose bounds intersect ‘r’.
+func (receiver *SpatialIndex) Intersecting(r image.Rectangle) []int {
+	if r.Empty() {
+		return nil
+	}
+
+	ids := append([]int(nil), receiver.unbounded...)
+
+	cells := receiver.cellRange(r)
+	for cy:=cells.Min.Y; cy<cells.Max.Y; cy++ {
+		for cx:=cells.Min.X; cx<cells.Max.X; cx++ {
+			cell := image.Point{cx,cy}
+
+			for _, id := range receiver.cells[cell] {
+				intersection := receiver.entries[id].bounds.Intersect(r)
+				if intersection.Empty() {
+					continue
+				}
+
+				// An image can be in many cells. So that it is only returned once,
+				// it is only returned from the cell containing the top-left corner of the intersection.
+				if receiver.cellOf(intersection.Min) != cell {
+					continue
+				}
+
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	sort.Ints(ids)
+
+	return ids
+}
+
+// Image returns a (lazily) composited view of every image in the index, with later inserted images on top.
+//
+// The returned image uses the index to only composite the images covering each pixel.
+// It reflects changes made to the index after it was returned.
+func (receiver *SpatialIndex) Image() image.Image {
+	return internalSpatialIndexImage{
+		index: receiver,
+	}
+}
+
+func (receiver *SpatialIndex) add(id int, img image.Image) {
+	if IsUnbounded(img) {
+		receiver.entries[id] = spatialEntry{
+			img: img,
+		}
+		receiver.unbounded = insertID(receiver.unbounded, id)
+		receiver.union = receiver.union.Union(img.Bounds())
+		return
+	}
+
+	bounds := img.Bounds()
+	receiver.union = receiver.union.Union(bounds)
+
+	receiver.entries[id] = spatialEntry{
+		img:    img,
+		bounds: bounds,
+	}
+
+	cells := receiver.cellRange(bounds)
+	for cy:=cells.Min.Y; cy<cells.Max.Y; cy++ {
+		for cx:=cells.Min.X; cx<cells.Max.X; cx++ {
+			receiver.addToCell(image.Point{cx,cy}, id)
+		}
+	}
+}
+
+func (receiver *SpatialIndex) addToCell(cell image.Point, id int) {
+	receiver.cells[cell] = insertID(receiver.cells[cell], id)
+}
+
+// shrinkUnion updates the union of the bounds of the images in the index, after an image with bounds ‘removed’ was removed (or moved).
+//
+// The union only needs recomputing if ‘removed’ was on its edge.
+func (receiver *SpatialIndex) shrinkUnion(removed image.Rectangle) {
+	union := receiver.union
+	if removed.Empty() || (union.Min.X < removed.Min.X && union.Min.Y < removed.Min.Y && removed.Max.X < union.Max.X && removed.Max.Y < union.Max.Y) {
+		return
+	}
+
+	union = image.Rectangle{}
+	for _, entry := range receiver.entries {
+		union = union.Union(entry.img.Bounds())
+	}
+
+	receiver.union = union
+}
+
+func (receiver *SpatialIndex) removeFromCell(cell image.Point, id int) {
+	ids := removeID(receiver.cells[cell], id)
+	if len(ids) <= 0 {
+		delete(receiver.cells, cell)
+		return
+	}
+
+	receiver.cells[cell] = ids
+}
+
+// cellOf returns the grid cell containing ‘p’.
+func (receiver *SpatialIndex) cellOf(p image.Point) image.Point {
+	return image.Point{
+		X: floorDiv(p.X, receiver.cellSize),
+		Y: floorDiv(p.Y, receiver.cellSize),
+	}
+}
+
+// cellRange returns the (half-open) range of grid cells that ‘r’ touches.
+func (receiver *SpatialIndex) cellRange(r image.Rectangle) image.Rectangle {
+	if r.Empty() {
+		return image.Rectangle{}
+	}
+
+	min := receiver.cellOf(r.Min)
+	max := receiver.cellOf(r.Max.Sub(image.Point{1,1}))
+
+	return image.Rectangle{
+		Min: min,
+		Max: max.Add(image.Point{1,1}),
+	}
+}
+
+// floorDiv returns ‘a’ divided by ‘b’, rounded towards negative infinity (rather than towards zero).
+func floorDiv(a, b int) int {
+	q := a / b
+	if (a % b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+
+	return q
+}
+
+// insertID inserts ‘id’ into the sorted ‘ids’, keeping it sorted.
+func insertID(ids []int, id int) []int {
+	i := sort.SearchInts(ids, id)
+
+	ids = append(ids, 0)
+	copy(ids[i+1:], ids[i:])
+	ids[i] = id
+
+	return ids
+}
+
+func removeID(ids []int, id int) []int {
+	for i, value := range ids {
+		if value == id {
+			return append(ids[:i], ids[i+1:]...)
+		}
+	}
+
+	return ids
+}
+
+// internalSpatialIndexImage is the composited view returned from SpatialIndex.Image().
+type internalSpatialIndexImage struct {
+	index *SpatialIndex
+}
+
+func (receiver internalSpatialIndexImage) At(x, y int) color.Color {
+	var c color.RGBA64
+
+	receiver.index.covering(image.Point{x,y}, func(_ int, entry spatialEntry) {
+		c = over(c, entry.img.At(x,y))
+	})
+
+	return c
+}
+
+// Bounds returns the union of the bounds of the images in the index.
+//
+// (If there are any unbounded images in the index, then this is the union of their stand-in bounds.)
+func (receiver internalSpatialIndexImage) Bounds() image.Rectangle {
+	return receiver.index.union
+}
+
+func (receiver internalSpatialIndexImage) ColorModel() color.Model {
+	return color.RGBA64Model
+}
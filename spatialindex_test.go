@@ -0,0 +1,191 @@
+package imagerelocate_test
+
+import (
+	"github.com/reiver/go-imagerelocate"
+
+	"image"
+	"image/color"
+	"math/rand"
+	"reflect"
+
+	"testing"
+)
+
+func TestSpatialIndex(t *testing.T) {
+
+	randomness := rand.New(rand.NewSource(1))
+
+	index := imagerelocate.NewSpatialIndex(16)
+
+	var images = map[int]image.Image{}
+
+	randomImage := func() image.Image {
+		src := image.NewRGBA(image.Rect(0,0, 1+randomness.Intn(40), 1+randomness.Intn(40)))
+		for i := range src.Pix {
+			src.Pix[i] = uint8(randomness.Intn(256))
+		}
+
+		return imagerelocate.Wrap(randomness.Intn(400)-200, randomness.Intn(400)-200, src)
+	}
+
+	for i:=0; i<200; i++ {
+		img := randomImage()
+		images[index.Insert(img)] = img
+	}
+
+	// Move some, and remove some.
+	for id:=0; id<200; id+=3 {
+		img := randomImage()
+		if !index.Update(id, img) {
+			t.Errorf("Could not update image #%d.", id)
+			continue
+		}
+		images[id] = img
+	}
+	for id:=1; id<200; id+=7 {
+		if !index.Remove(id) {
+			t.Errorf("Could not remove image #%d.", id)
+			continue
+		}
+		delete(images, id)
+	}
+
+	if expected, actual := len(images), index.Len(); expected != actual {
+		t.Errorf("The actual length is not what was expected.")
+		t.Logf("EXPECTED: %d", expected)
+		t.Logf("ACTUAL:   %d", actual)
+	}
+
+	{
+		var expected image.Rectangle
+		for _, img := range images {
+			expected = expected.Union(img.Bounds())
+		}
+
+		if actual := index.Image().Bounds(); expected != actual {
+			t.Errorf("The actual bounds are not what was expected.")
+			t.Logf("EXPECTED: %#v", expected)
+			t.Logf("ACTUAL:   %#v", actual)
+		}
+	}
+
+	bruteForce := func(fn func(image.Image) bool) []int {
+		var ids []int
+		for id:=0; id<200; id++ {
+			if img, found := images[id]; found && fn(img) {
+				ids = append(ids, id)
+			}
+		}
+		return ids
+	}
+
+	for testNumber:=0; testNumber<100; testNumber++ {
+		p := image.Pt(randomness.Intn(500)-250, randomness.Intn(500)-250)
+		r := image.Rect(p.X, p.Y, p.X+randomness.Intn(60), p.Y+randomness.Intn(60))
+
+		{
+			expected := bruteForce(func(img image.Image) bool {
+				return p.In(img.Bounds())
+			})
+			actual := index.CoveringPoint(p)
+
+			if !reflect.DeepEqual(expected, actual) {
+				t.Errorf("For test #%d, the actual IDs covering the point are not what was expected.", testNumber)
+				t.Logf("POINT:    %#v", p)
+				t.Logf("EXPECTED: %#v", expected)
+				t.Logf("ACTUAL:   %#v", actual)
+				continue
+			}
+
+			var expectedColor color.RGBA64
+			for _, id := range expected {
+				// Compositing over with "over" by hand.
+				r,g,b,a := images[id].At(p.X, p.Y).RGBA()
+				ia := 0xffff - a
+				expectedColor = color.RGBA64{
+					R: uint16(r + uint32(expectedColor.R)*ia/0xffff),
+					G: uint16(g + uint32(expectedColor.G)*ia/0xffff),
+					B: uint16(b + uint32(expectedColor.B)*ia/0xffff),
+					A: uint16(a + uint32(expectedColor.A)*ia/0xffff),
+				}
+			}
+
+			if expected, actual := expectedColor, index.Image().At(p.X, p.Y); expected != actual {
+				t.Errorf("For test #%d, the actual composited color is not what was expected.", testNumber)
+				t.Logf("EXPECTED: %#v", expected)
+				t.Logf("ACTUAL:   %#v", actual)
+				continue
+			}
+		}
+
+		{
+			expected := bruteForce(func(img image.Image) bool {
+				return img.Bounds().Overlaps(r)
+			})
+			actual := index.Intersecting(r)
+
+			if !reflect.DeepEqual(expected, actual) {
+				t.Errorf("For test #%d, the actual IDs intersecting the rectangle are not what was expected.", testNumber)
+				t.Logf("RECTANGLE: %#v", r)
+				t.Logf("EXPECTED:  %#v", expected)
+				t.Logf("ACTUAL:    %#v", actual)
+				continue
+			}
+		}
+	}
+}
+
+func TestSpatialIndex_Image(t *testing.T) {
+
+	palette := color.Palette{color.Transparent, color.RGBA{R:255, A:255}, color.RGBA{G:128, A:128}}
+
+	square := func(x, y, size int, index uint8) image.Image {
+		img := image.NewPaletted(image.Rect(0,0, size,size), palette)
+		for i := range img.Pix {
+			img.Pix[i] = index
+		}
+
+		return imagerelocate.Wrap(x,y, img)
+	}
+
+	index := imagerelocate.NewSpatialIndex(16)
+	composited := index.Image()
+
+	index.Insert(image.NewUniform(color.RGBA{B:255, A:255}))
+	index.Insert(square(0,0, 10, 1))
+	index.Insert(square(5,5, 10, 2))
+	index.Insert(square(8,8, 4, 2))
+
+	// Looking up a pixel should not allocate, other than for the returned color.
+	if allocs := testing.AllocsPerRun(100, func(){ composited.At(9,9) }); 1 < allocs {
+		t.Errorf("Expected .At() to allocate at most once, but it allocated %v times.", allocs)
+	}
+
+	// The bounds follow images being moved and removed.
+	bounded := imagerelocate.NewSpatialIndex(16)
+	bounded.Insert(square(0,0, 10, 1))
+	c := bounded.Insert(square(20,30, 10, 1))
+
+	if expected, actual := image.Rect(0,0, 30,40), bounded.Image().Bounds(); expected != actual {
+		t.Errorf("The actual bounds are not what was expected.")
+		t.Logf("EXPECTED: %#v", expected)
+		t.Logf("ACTUAL:   %#v", actual)
+	}
+
+	bounded.Update(c, square(5,5, 2, 1))
+
+	if expected, actual := image.Rect(0,0, 10,10), bounded.Image().Bounds(); expected != actual {
+		t.Errorf("The actual bounds after moving are not what was expected.")
+		t.Logf("EXPECTED: %#v", expected)
+		t.Logf("ACTUAL:   %#v", actual)
+	}
+
+	bounded.Remove(c)
+	bounded.Insert(square(-3,-4, 2, 1))
+
+	if expected, actual := image.Rect(-3,-4, 10,10), bounded.Image().Bounds(); expected != actual {
+		t.Errorf("The actual bounds after removing and inserting are not what was expected.")
+		t.Logf("EXPECTED: %#v", expected)
+		t.Logf("ACTUAL:   %#v", actual)
+	}
+}
@@ -0,0 +1,72 @@
+package imagerelocate
+
+import (
+	"image"
+	"image/color"
+)
+
+// tileExtent is how far a tiled axis extends in each direction.
+//
+// This matches how far Go's built-in image.Uniform extends.
+const tileExtent = 1e9
+
+// internalTiledImage repeats an image along the x-axis, the y-axis, or both.
+type internalTiledImage struct {
+	img image.Image
+	tileX, tileY bool
+}
+
+var _ Unbounded = internalTiledImage{}
+
+func (receiver internalTiledImage) At(x, y int) color.Color {
+	bounds := receiver.img.Bounds()
+	if bounds.Empty() {
+		return color.Transparent
+	}
+
+	if receiver.tileX {
+		x = wrapAround(x, bounds.Min.X, bounds.Max.X)
+	}
+	if receiver.tileY {
+		y = wrapAround(y, bounds.Min.Y, bounds.Max.Y)
+	}
+
+	return receiver.img.At(x,y)
+}
+
+func (receiver internalTiledImage) Bounds() image.Rectangle {
+	bounds := receiver.img.Bounds()
+	if bounds.Empty() {
+		return bounds
+	}
+
+	if receiver.tileX {
+		bounds.Min.X, bounds.Max.X = -tileExtent, tileExtent
+	}
+	if receiver.tileY {
+		bounds.Min.Y, bounds.Max.Y = -tileExtent, tileExtent
+	}
+
+	return bounds
+}
+
+func (receiver internalTiledImage) ColorModel() color.Model {
+	return receiver.img.ColorModel()
+}
+
+// Unbounded returns true only when tiling along both axes, since only then is there no real bound left to relocate.
+func (receiver internalTiledImage) Unbounded() bool {
+	return receiver.tileX && receiver.tileY
+}
+
+// wrapAround maps ‘n’ into [‘min’,‘max’), repeating.
+func wrapAround(n, min, max int) int {
+	length := max - min
+
+	n = (n - min) % length
+	if n < 0 {
+		n += length
+	}
+
+	return min + n
+}
@@ -0,0 +1,163 @@
+package imagerelocate
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+var errBadTileSize = errors.New("imagerelocate: tile size must be positive")
+
+// TileFlip is how a tile in a TileSet's map is mirrored.
+type TileFlip uint8
+
+const (
+	TileFlipNone TileFlip = 0
+	TileFlipX    TileFlip = 1 << 0 // mirrored horizontally
+	TileFlipY    TileFlip = 1 << 1 // mirrored vertically
+)
+
+// TileMapEntry is one cell of a TileSet's map — which tile goes there, and how it is mirrored.
+type TileMapEntry struct {
+	Index int
+	Flip  TileFlip
+}
+
+// TileSet is an image converted into a set of unique tiles, plus a map of which tile goes where —
+// for example, for converting full-screen artwork into 8×8 tiles (such as sprite8x8 uses).
+//
+// ‘Tiles’ are the unique tiles, each with its top-left corner at (0,0).
+//
+// ‘Map’ has ‘Columns’×‘Rows’ entries, from top to bottom and left to right.
+// The tile for column ‘c’ and row ‘r’ is Map[r*Columns + c], and its top-left corner goes at
+// ‘Origin’ + (c×TileSize.X, r×TileSize.Y).
+//
+// ‘Bounds’ are the bounds of the original image.
+type TileSet struct {
+	TileSize image.Point
+	Tiles    []*image.RGBA64
+	Map      []TileMapEntry
+	Columns  int
+	Rows     int
+	Origin   image.Point
+	Bounds   image.Rectangle
+}
+
+// NewTileSet slices ‘img’ into ‘tileSize’ tiles, and deduplicates them.
+//
+// If ‘allowFlips’ is true, then a tile that is a mirror image (horizontally, vertically, or both) of an earlier tile
+// is treated as the same tile, and its map entry records the flip.
+//
+// If the bounds of ‘img’ are not a multiple of ‘tileSize’, then the tiles on the right and bottom edges are padded with transparent pixels.
+//
+// ‘img’ must be bounded (see IsUnbounded).
+func NewTileSet(img image.Image, tileSize image.Point, allowFlips bool) (*TileSet, error) {
+	if tileSize.X <= 0 || tileSize.Y <= 0 {
+		return nil, errBadTileSize
+	}
+
+	bounds := img.Bounds()
+
+	set := &TileSet{
+		TileSize: tileSize,
+		Columns:  (bounds.Dx() + tileSize.X - 1) / tileSize.X,
+		Rows:     (bounds.Dy() + tileSize.Y - 1) / tileSize.Y,
+		Origin:   bounds.Min,
+		Bounds:   bounds,
+	}
+
+	set.Map = make([]TileMapEntry, 0, set.Columns*set.Rows)
+
+	seen := map[string]TileMapEntry{}
+
+	flips := []TileFlip{TileFlipNone}
+	if allowFlips {
+		flips = append(flips, TileFlipX, TileFlipY, TileFlipX|TileFlipY)
+	}
+
+	normalized := image.Rectangle{Max:tileSize}
+
+	for row:=0; row<set.Rows; row++ {
+		for column:=0; column<set.Columns; column++ {
+			cell := set.Origin.Add(image.Point{column*tileSize.X, row*tileSize.Y})
+
+			tile := image.NewRGBA64(normalized)
+			Draw(tile, normalized, img, cell, draw.Src)
+
+			entry, found := TileMapEntry{}, false
+			for _, f := range flips {
+				if entry, found = seen[tileKey(tile, f)]; found {
+					entry.Flip ^= f
+					break
+				}
+			}
+
+			if !found {
+				entry = TileMapEntry{Index:len(set.Tiles)}
+				set.Tiles = append(set.Tiles, tile)
+				seen[tileKey(tile, TileFlipNone)] = entry
+			}
+
+			set.Map = append(set.Map, entry)
+		}
+	}
+
+	return set, nil
+}
+
+// tileKey returns the pixels of ‘tile’, mirrored by ‘f’, as a string that can be used as a map key.
+func tileKey(tile *image.RGBA64, f TileFlip) string {
+	if TileFlipNone == f {
+		return string(tile.Pix)
+	}
+
+	bounds := tile.Rect
+	flipped := image.NewRGBA64(bounds)
+	draw.Draw(flipped, bounds, flip(tile, 0 != f&TileFlipX, 0 != f&TileFlipY), bounds.Min, draw.Src)
+
+	return string(flipped.Pix)
+}
+
+// Tile returns the tile at column ‘column’ and row ‘row’ of the map — mirrored as the map says, and relocated to where it goes.
+func (receiver *TileSet) Tile(column, row int) image.Image {
+	entry := receiver.Map[row*receiver.Columns + column]
+
+	tile := flip(receiver.Tiles[entry.Index], 0 != entry.Flip&TileFlipX, 0 != entry.Flip&TileFlipY)
+	cell := receiver.Origin.Add(image.Point{column*receiver.TileSize.X, row*receiver.TileSize.Y})
+
+	return Wrap(cell.X, cell.Y, tile)
+}
+
+// Image returns the (lazily) rendered tile map — which reproduces the original image exactly.
+func (receiver *TileSet) Image() image.Image {
+	return internalTileSetImage{
+		set: receiver,
+	}
+}
+
+// internalTileSetImage is the image returned from TileSet.Image().
+type internalTileSetImage struct {
+	set *TileSet
+}
+
+func (receiver internalTileSetImage) At(x, y int) color.Color {
+	set := receiver.set
+
+	if !(image.Point{x,y}).In(set.Bounds) {
+		return color.Transparent
+	}
+
+	column := (x - set.Origin.X) / set.TileSize.X
+	row := (y - set.Origin.Y) / set.TileSize.Y
+
+	return set.Tile(column, row).At(x,y)
+}
+
+func (receiver internalTileSetImage) Bounds() image.Rectangle {
+	return receiver.set.Bounds
+}
+
+func (receiver internalTileSetImage) ColorModel() color.Model {
+	return color.RGBA64Model
+}
@@ -0,0 +1,90 @@
+package imagerelocate_test
+
+import (
+	"github.com/reiver/go-imagerelocate"
+
+	"image"
+	"image/color"
+	"image/draw"
+
+	"testing"
+)
+
+func TestNewTileSet(t *testing.T) {
+
+	// A 2×2 tile with a single red pixel in its top-left corner.
+	corner := image.NewRGBA(image.Rect(0,0, 2,2))
+	corner.Set(0,0, color.RGBA{R:255, A:255})
+
+	// The same tile, mirrored horizontally.
+	mirrored := image.NewRGBA(image.Rect(0,0, 2,2))
+	mirrored.Set(1,0, color.RGBA{R:255, A:255})
+
+	// A 6×5 image (which is not a multiple of the tile size), made of those tiles.
+	img := image.NewRGBA(image.Rect(-3,7, 3,12))
+	draw.Draw(img, image.Rect(-3,7, -1,9), corner, image.Point{}, draw.Src)
+	draw.Draw(img, image.Rect(-1,7, 1,9), corner, image.Point{}, draw.Src)
+	draw.Draw(img, image.Rect(1,7, 3,9), mirrored, image.Point{}, draw.Src)
+	img.Set(2,11, color.RGBA{B:255, A:255})
+
+	tests := []struct{
+		AllowFlips bool
+		ExpectedTiles int
+	}{
+		{
+			AllowFlips: false,
+			// corner, mirrored, blank, and the partial tile with the blue pixel.
+			ExpectedTiles: 4,
+		},
+		{
+			AllowFlips: true,
+			ExpectedTiles: 3,
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		set, err := imagerelocate.NewTileSet(img, image.Pt(2,2), test.AllowFlips)
+		if nil != err {
+			t.Errorf("For test #%d, did not expect an error but actually got one.", testNumber)
+			t.Logf("ERROR: (%T) %s", err, err)
+			continue
+		}
+
+		if expected, actual := test.ExpectedTiles, len(set.Tiles); expected != actual {
+			t.Errorf("For test #%d, the actual number of unique tiles is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %d", expected)
+			t.Logf("ACTUAL:   %d", actual)
+			continue
+		}
+
+		if expected, actual := 3*3, len(set.Map); expected != actual {
+			t.Errorf("For test #%d, the actual size of the tile map is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %d", expected)
+			t.Logf("ACTUAL:   %d", actual)
+			continue
+		}
+
+		rendered := set.Image()
+
+		if expected, actual := img.Bounds(), rendered.Bounds(); expected != actual {
+			t.Errorf("For test #%d, the actual rendered bounds are not what was expected.", testNumber)
+			t.Logf("EXPECTED: %#v", expected)
+			t.Logf("ACTUAL:   %#v", actual)
+			continue
+		}
+
+		for y:=img.Rect.Min.Y; y<img.Rect.Max.Y; y++ {
+			for x:=img.Rect.Min.X; x<img.Rect.Max.X; x++ {
+				expected := color.RGBA64Model.Convert(img.At(x,y))
+				actual   := color.RGBA64Model.Convert(rendered.At(x,y))
+
+				if expected != actual {
+					t.Errorf("For test #%d, the actual rendered color at (%d,%d) is not what was expected.", testNumber, x,y)
+					t.Logf("EXPECTED: %#v", expected)
+					t.Logf("ACTUAL:   %#v", actual)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package imagerelocate
+
+import (
+	"image"
+	"math"
+)
+
+// addSaturating returns ‘a’+‘b’, except that rather than overflowing it saturates at math.MaxInt or math.MinInt.
+//
+// (On 32-bit targets, the huge bounds some images use can overflow when shifted.)
+func addSaturating(a, b int) int {
+	if 0 < b && math.MaxInt-b < a {
+		return math.MaxInt
+	}
+	if b < 0 && a < math.MinInt-b {
+		return math.MinInt
+	}
+
+	return a + b
+}
+
+// translate returns ‘r’ shifted by (‘dx’, ‘dy’), saturating rather than overflowing.
+func translate(r image.Rectangle, dx, dy int) image.Rectangle {
+	r.Min.X = addSaturating(r.Min.X, dx)
+	r.Min.Y = addSaturating(r.Min.Y, dy)
+
+	r.Max.X = addSaturating(r.Max.X, dx)
+	r.Max.Y = addSaturating(r.Max.Y, dy)
+
+	return r
+}
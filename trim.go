@@ -0,0 +1,140 @@
+package imagerelocate
+
+import (
+	"image"
+)
+
+// Trim returns a view of only the part of ‘img’ that is not transparent — i.e., with its transparent borders cropped off.
+//
+// A pixel counts as not transparent if its (8-bit) alpha is greater than ‘alphaThreshold’.
+// (So an ‘alphaThreshold’ of 0 keeps every pixel that is not fully transparent.)
+//
+// The returned image keeps the coordinates of ‘img’. See TrimNormalized for a version relocated to (0,0).
+//
+// If every pixel is transparent, then the returned image has empty bounds.
+func Trim(img image.Image, alphaThreshold uint8) image.Image {
+	return crop(img, TrimBounds(img, alphaThreshold))
+}
+
+// TrimNormalized is like Trim, except that the returned image is relocated so that its top-left corner is at (0,0).
+//
+// It also returns where the top-left corner was — i.e., relocating the returned image by ‘offset’ puts it back where it was in ‘img’.
+func TrimNormalized(img image.Image, alphaThreshold uint8) (trimmed image.Image, offset image.Point) {
+	bounds := TrimBounds(img, alphaThreshold)
+
+	return Wrap(-bounds.Min.X, -bounds.Min.Y, crop(img, bounds)), bounds.Min
+}
+
+// TrimBounds returns the tightest rectangle containing every pixel in ‘img’ whose (8-bit) alpha is greater than ‘alphaThreshold’.
+//
+// If there is no such pixel, then an empty rectangle is returned.
+//
+// If ‘img’ is unbounded (see IsUnbounded), then its bounds are returned as is.
+func TrimBounds(img image.Image, alphaThreshold uint8) image.Rectangle {
+	if IsUnbounded(img) {
+		return img.Bounds()
+	}
+
+	src, offset := unwrap(img)
+	alphaAt := alphaFunc(src)
+	bounds := src.Bounds()
+
+	hit := func(x, y int) bool {
+		return alphaThreshold < alphaAt(x,y)
+	}
+
+	rowHit := func(y, minX, maxX int) bool {
+		for x:=minX; x<maxX; x++ {
+			if hit(x,y) {
+				return true
+			}
+		}
+		return false
+	}
+
+	columnHit := func(x, minY, maxY int) bool {
+		for y:=minY; y<maxY; y++ {
+			if hit(x,y) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var trimmed image.Rectangle
+
+	// Top.
+	{
+		y := bounds.Min.Y
+		for ; y<bounds.Max.Y; y++ {
+			if rowHit(y, bounds.Min.X, bounds.Max.X) {
+				break
+			}
+		}
+		if bounds.Max.Y <= y {
+			return image.Rectangle{}
+		}
+		trimmed.Min.Y = y
+	}
+
+	// Bottom.
+	{
+		y := bounds.Max.Y-1
+		for ; trimmed.Min.Y<y; y-- {
+			if rowHit(y, bounds.Min.X, bounds.Max.X) {
+				break
+			}
+		}
+		trimmed.Max.Y = y+1
+	}
+
+	// Left.
+	{
+		x := bounds.Min.X
+		for ; x<bounds.Max.X; x++ {
+			if columnHit(x, trimmed.Min.Y, trimmed.Max.Y) {
+				break
+			}
+		}
+		trimmed.Min.X = x
+	}
+
+	// Right.
+	{
+		x := bounds.Max.X-1
+		for ; trimmed.Min.X<x; x-- {
+			if columnHit(x, trimmed.Min.Y, trimmed.Max.Y) {
+				break
+			}
+		}
+		trimmed.Max.X = x+1
+	}
+
+	return trimmed.Add(offset)
+}
+
+// alphaFunc returns a func that returns the (8-bit) alpha of the pixel at (x,y) in ‘img’.
+//
+// For *image.RGBA, *image.NRGBA, and *image.Alpha the alpha is read directly from .Pix,
+// rather than going through .At().
+func alphaFunc(img image.Image) func(x, y int) uint8 {
+	switch casted := img.(type) {
+	case *image.RGBA:
+		return func(x, y int) uint8 {
+			return casted.Pix[casted.PixOffset(x,y)+3]
+		}
+	case *image.NRGBA:
+		return func(x, y int) uint8 {
+			return casted.Pix[casted.PixOffset(x,y)+3]
+		}
+	case *image.Alpha:
+		return func(x, y int) uint8 {
+			return casted.Pix[casted.PixOffset(x,y)]
+		}
+	default:
+		return func(x, y int) uint8 {
+			_,_,_,a := img.At(x,y).RGBA()
+			return uint8(a>>8)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package imagerelocate_test
+
+import (
+	"github.com/reiver/go-imagerelocate"
+
+	"image"
+	"image/color"
+
+	"testing"
+)
+
+// onlyAt hides the concrete type of an image, so that the generic (non-fast-path) code gets tested.
+type onlyAt struct {
+	image.Image
+}
+
+func TestTrim(t *testing.T) {
+
+	rgba := image.NewRGBA(image.Rect(0,0, 20,10))
+	rgba.Set(4,3, color.RGBA{R:255, A:255})
+	rgba.Set(9,6, color.RGBA{A:10})
+
+	nrgba := image.NewNRGBA(image.Rect(-5,-5, 5,5))
+	nrgba.Set(-2,0, color.NRGBA{G:255, A:255})
+
+	alpha := image.NewAlpha(image.Rect(0,0, 8,8))
+
+	tests := []struct{
+		Image image.Image
+		AlphaThreshold uint8
+		Expected image.Rectangle
+	}{
+		{
+			Image: rgba,
+			Expected: image.Rect(4,3, 10,7),
+		},
+		{
+			Image: rgba,
+			AlphaThreshold: 10,
+			Expected: image.Rect(4,3, 5,4),
+		},
+		{
+			Image: onlyAt{rgba},
+			Expected: image.Rect(4,3, 10,7),
+		},
+		{
+			Image: imagerelocate.Wrap(100,200, rgba),
+			Expected: image.Rect(104,203, 110,207),
+		},
+		{
+			Image: nrgba,
+			Expected: image.Rect(-2,0, -1,1),
+		},
+		{
+			Image: alpha,
+			Expected: image.Rectangle{},
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		if expected, actual := test.Expected, imagerelocate.TrimBounds(test.Image, test.AlphaThreshold); expected != actual {
+			t.Errorf("For test #%d, the actual trimmed bounds are not what was expected.", testNumber)
+			t.Logf("EXPECTED: %#v", expected)
+			t.Logf("ACTUAL:   %#v", actual)
+			continue
+		}
+
+		trimmed := imagerelocate.Trim(test.Image, test.AlphaThreshold)
+
+		if expected, actual := test.Expected, trimmed.Bounds(); expected != actual {
+			t.Errorf("For test #%d, the actual bounds of the trimmed image are not what was expected.", testNumber)
+			t.Logf("EXPECTED: %#v", expected)
+			t.Logf("ACTUAL:   %#v", actual)
+			continue
+		}
+
+		normalized, offset := imagerelocate.TrimNormalized(test.Image, test.AlphaThreshold)
+
+		if expected, actual := test.Expected.Min, offset; expected != actual {
+			t.Errorf("For test #%d, the actual offset is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %#v", expected)
+			t.Logf("ACTUAL:   %#v", actual)
+			continue
+		}
+
+		if expected, actual := test.Expected.Sub(test.Expected.Min), normalized.Bounds(); expected != actual {
+			t.Errorf("For test #%d, the actual bounds of the normalized image are not what was expected.", testNumber)
+			t.Logf("EXPECTED: %#v", expected)
+			t.Logf("ACTUAL:   %#v", actual)
+			continue
+		}
+
+		for y:=test.Expected.Min.Y; y<test.Expected.Max.Y; y++ {
+			for x:=test.Expected.Min.X; x<test.Expected.Max.X; x++ {
+				if expected, actual := test.Image.At(x,y), normalized.At(x-offset.X, y-offset.Y); expected != actual {
+					t.Errorf("For test #%d, the actual color at (%d,%d) is not what was expected.", testNumber, x,y)
+					t.Logf("EXPECTED: %#v", expected)
+					t.Logf("ACTUAL:   %#v", actual)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package imagerelocate
+
+import (
+	"image"
+)
+
+// Unbounded is an optional interface an image.Image can implement to declare that its bounds
+// are not real bounds, but instead a stand-in for an infinite plane.
+//
+// For example, Go's built-in image.Uniform returns a rectangle spanning about ±1e9 from its
+// .Bounds() method, even though it (conceptually) has no bounds at all.
+//
+// Images that are unbounded keep their bounds when relocated.
+type Unbounded interface {
+	Unbounded() bool
+}
+
+// IsUnbounded returns whether ‘img’ is an infinite (unbounded) image.
+//
+// An *image.Uniform is always considered unbounded.
+// Any other image is unbounded if it implements Unbounded and its .Unbounded() method returns true.
+func IsUnbounded(img image.Image) bool {
+	switch casted := img.(type) {
+	case *image.Uniform:
+		return true
+	case Unbounded:
+		return casted.Unbounded()
+	default:
+		return false
+	}
+}
+
+// clip returns the part of ‘r’ that ‘img’ actually covers.
+//
+// For a bounded image this is the intersection of ‘r’ and the image's bounds.
+// For an unbounded image, ‘r’ is returned as is, so that helpers never iterate over
+// the huge stand-in bounds of an infinite image.
+func clip(img image.Image, r image.Rectangle) image.Rectangle {
+	if IsUnbounded(img) {
+		return r
+	}
+
+	return r.Intersect(img.Bounds())
+}
@@ -0,0 +1,116 @@
+package imagerelocate_test
+
+import (
+	"github.com/reiver/go-imagerelocate"
+
+	"image"
+	"image/color"
+	"math"
+
+	"testing"
+)
+
+type unboundedChecker struct {
+	image.Image
+}
+
+func (unboundedChecker) Unbounded() bool {
+	return true
+}
+
+func TestWrap_uniform(t *testing.T) {
+
+	tests := []struct{
+		DX int
+		DY int
+	}{
+		{
+			DX: 0,
+			DY: 0,
+		},
+		{
+			DX: 5,
+			DY: -7,
+		},
+		{
+			DX: math.MaxInt,
+			DY: math.MinInt,
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		var src *image.Uniform = image.NewUniform(color.RGBA{R:1, G:2, B:3, A:255})
+
+		var img image.Image = imagerelocate.Wrap(test.DX, test.DY, src)
+
+		if expected, actual := src.Bounds(), img.Bounds(); expected != actual {
+			t.Errorf("For test #%d, the actual bounds are not what was expected.", testNumber)
+			t.Logf("EXPECTED: %#v", expected)
+			t.Logf("ACTUAL:   %#v", actual)
+			continue
+		}
+
+		if !imagerelocate.IsUnbounded(img) {
+			t.Errorf("For test #%d, expected the relocated image to still be unbounded, but it wasn't.", testNumber)
+			continue
+		}
+
+		if expected, actual := src.At(0,0), img.At(12,34); expected != actual {
+			t.Errorf("For test #%d, the actual color is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %#v", expected)
+			t.Logf("ACTUAL:   %#v", actual)
+			continue
+		}
+	}
+}
+
+func TestIsUnbounded(t *testing.T) {
+
+	tests := []struct{
+		Image image.Image
+		Expected bool
+	}{
+		{
+			Image: image.NewUniform(color.Black),
+			Expected: true,
+		},
+		{
+			Image: image.NewRGBA(image.Rect(0,0, 4,4)),
+			Expected: false,
+		},
+		{
+			Image: unboundedChecker{image.NewRGBA(image.Rect(0,0, 4,4))},
+			Expected: true,
+		},
+		{
+			Image: imagerelocate.Wrap(3,4, image.NewRGBA(image.Rect(0,0, 4,4))),
+			Expected: false,
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		if expected, actual := test.Expected, imagerelocate.IsUnbounded(test.Image); expected != actual {
+			t.Errorf("For test #%d, the actual value is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %t", expected)
+			t.Logf("ACTUAL:   %t", actual)
+			continue
+		}
+	}
+}
+
+func TestWrap_saturates(t *testing.T) {
+
+	src := image.NewRGBA(image.Rect(math.MaxInt-10, 0, math.MaxInt-5, 1))
+
+	img := imagerelocate.Wrap(100, 0, src)
+
+	expected := image.Rect(math.MaxInt, 0, math.MaxInt, 1)
+
+	if actual := img.Bounds(); expected != actual {
+		t.Errorf("The actual bounds are not what was expected.")
+		t.Logf("EXPECTED: %#v", expected)
+		t.Logf("ACTUAL:   %#v", actual)
+	}
+}
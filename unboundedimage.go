@@ -0,0 +1,36 @@
+package imagerelocate
+
+import (
+	"image"
+	"image/color"
+)
+
+// internalUnboundedImage is a relocated infinite (unbounded) image.
+//
+// Its bounds are the (stand-in) bounds of the source image, and are NOT shifted,
+// because shifting them could overflow and an infinite plane that is relocated is still an infinite plane.
+type internalUnboundedImage struct {
+	img image.Image
+	x,y int
+}
+
+var _ Unbounded = internalUnboundedImage{}
+
+func (receiver internalUnboundedImage) At(x, y int) color.Color {
+	x -= receiver.x
+	y -= receiver.y
+
+	return receiver.img.At(x,y)
+}
+
+func (receiver internalUnboundedImage) Bounds() image.Rectangle {
+	return receiver.img.Bounds()
+}
+
+func (receiver internalUnboundedImage) ColorModel() color.Model {
+	return receiver.img.ColorModel()
+}
+
+func (receiver internalUnboundedImage) Unbounded() bool {
+	return true
+}
@@ -0,0 +1,49 @@
+package imagerelocate
+
+import (
+	"image"
+)
+
+// relocated is implemented by the images this package returns from Wrap (and similar).
+//
+// It gives access to the image that was relocated, and by how much it was relocated.
+type relocated interface {
+	relocatedSource() (image.Image, image.Point)
+}
+
+func (receiver internalImage) relocatedSource() (image.Image, image.Point) {
+	return receiver.img, image.Point{receiver.x, receiver.y}
+}
+
+func (receiver internalUnboundedImage) relocatedSource() (image.Image, image.Point) {
+	return receiver.img, image.Point{receiver.x, receiver.y}
+}
+
+// relocatable is implemented by images that can relocate themselves cheaply (such as *Buffer),
+// which Wrap uses rather than wrapping them.
+type relocatable interface {
+	relocate(dx, dy int) image.Image
+}
+
+// unwrap sees through (any number of) relocated wrappers around ‘img’.
+//
+// It returns the original image, and the total offset it was relocated by.
+// So that, for any (x,y):
+//
+//	img.At(x,y) == src.At(x-offset.X, y-offset.Y)
+//
+// If ‘img’ was not relocated, then ‘img’ itself is returned, with an offset of (0,0).
+func unwrap(img image.Image) (src image.Image, offset image.Point) {
+	src = img
+
+	for {
+		r, casted := src.(relocated)
+		if !casted {
+			return src, offset
+		}
+
+		var delta image.Point
+		src, delta = r.relocatedSource()
+		offset = offset.Add(delta)
+	}
+}
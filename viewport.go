@@ -0,0 +1,202 @@
+package imagerelocate
+
+import (
+	"image"
+	"image/color"
+	"math"
+)
+
+// Viewport is a camera looking at a world image.
+//
+// It is itself an image.Image, which presents the region of ‘World’ that is visible
+// through ‘Camera’ as an image whose top-left corner is at (0,0).
+//
+// For example:
+//
+//	var view imagerelocate.Viewport = imagerelocate.Viewport{
+//		World:  world,
+//		Camera: image.Rect(scrollX, scrollY, scrollX+640, scrollY+480),
+//	}
+//
+//	draw.Draw(screen, screen.Bounds(), view.Frame(), image.Point{}, draw.Src)
+//
+// (Drawing ‘view’ itself also works; but .Frame() works out the camera once for the whole frame,
+// rather than for each pixel.)
+//
+// ‘Camera’ is in world coordinates.
+//
+// ‘Zoom’ is the magnification; a ‘Zoom’ of 2 makes each world pixel 2×2 screen pixels,
+// and a ‘Zoom’ of 0.5 shows twice as much of the world in each direction.
+// A zero (or negative) ‘Zoom’ is treated as 1.
+//
+// If ‘ClampToWorld’ is true, then the camera is kept inside the bounds of ‘World’
+// (unless ‘World’ is unbounded, see IsUnbounded).
+type Viewport struct {
+	World        image.Image
+	Camera       image.Rectangle
+	Zoom         float64
+	ClampToWorld bool
+}
+
+var _ image.Image = Viewport{}
+
+func (receiver Viewport) zoom() float64 {
+	if receiver.Zoom <= 0 {
+		return 1
+	}
+
+	return receiver.Zoom
+}
+
+// VisibleCamera returns the camera rectangle actually used, in world coordinates.
+//
+// This is ‘Camera’, after clamping it to the world bounds if ‘ClampToWorld’ is true.
+func (receiver Viewport) VisibleCamera() image.Rectangle {
+	camera := receiver.Camera.Canon()
+
+	if !receiver.ClampToWorld || nil == receiver.World || IsUnbounded(receiver.World) {
+		return camera
+	}
+
+	world := receiver.World.Bounds()
+
+	camera.Min.X, camera.Max.X = clampSpan(camera.Min.X, camera.Max.X, world.Min.X, world.Max.X)
+	camera.Min.Y, camera.Max.Y = clampSpan(camera.Min.Y, camera.Max.Y, world.Min.Y, world.Max.Y)
+
+	return camera
+}
+
+// clampSpan slides [‘min’,‘max’) so that it is inside of [‘lower’,‘upper’), without changing its length.
+//
+// If [‘min’,‘max’) is longer than [‘lower’,‘upper’), it is aligned with ‘lower’.
+func clampSpan(min, max, lower, upper int) (int, int) {
+	length := max - min
+
+	if upper-lower <= length {
+		return lower, lower+length
+	}
+	if min < lower {
+		return lower, lower+length
+	}
+	if upper < max {
+		return upper-length, upper
+	}
+
+	return min, max
+}
+
+// Frame returns the image the viewport presents, for the current ‘World’, ‘Camera’, ‘Zoom’, and ‘ClampToWorld’.
+//
+// The camera is worked out once, when Frame is called; so the returned image does not change if the viewport is changed afterwards.
+func (receiver Viewport) Frame() image.Image {
+	return receiver.frame()
+}
+
+func (receiver Viewport) frame() internalViewportFrame {
+	camera := receiver.VisibleCamera()
+	zoom := receiver.zoom()
+
+	width  := int(math.Ceil(float64(camera.Dx()) * zoom))
+	height := int(math.Ceil(float64(camera.Dy()) * zoom))
+
+	return internalViewportFrame{
+		world: internalImage{
+			img: receiver.World,
+			x:   -camera.Min.X,
+			y:   -camera.Min.Y,
+		},
+		screen: image.Rect(0,0, width,height),
+		zoom:   zoom,
+	}
+}
+
+func (receiver Viewport) At(x, y int) color.Color {
+	return receiver.frame().At(x,y)
+}
+
+// Bounds returns the bounds of the screen, which always has its top-left corner at (0,0).
+func (receiver Viewport) Bounds() image.Rectangle {
+	return receiver.frame().Bounds()
+}
+
+func (receiver Viewport) ColorModel() color.Model {
+	if nil == receiver.World {
+		return color.RGBA64Model
+	}
+
+	return receiver.World.ColorModel()
+}
+
+// ScreenToWorld converts a point in screen coordinates to world coordinates.
+func (receiver Viewport) ScreenToWorld(p image.Point) image.Point {
+	camera := receiver.VisibleCamera()
+	zoom := receiver.zoom()
+
+	return image.Point{
+		X: camera.Min.X + int(math.Floor(float64(p.X) / zoom)),
+		Y: camera.Min.Y + int(math.Floor(float64(p.Y) / zoom)),
+	}
+}
+
+// WorldToScreen converts a point in world coordinates to screen coordinates.
+//
+// When zoomed in, this returns the top-left screen pixel of the world pixel.
+func (receiver Viewport) WorldToScreen(p image.Point) image.Point {
+	camera := receiver.VisibleCamera()
+	zoom := receiver.zoom()
+
+	return image.Point{
+		X: int(math.Floor(float64(p.X - camera.Min.X) * zoom)),
+		Y: int(math.Floor(float64(p.Y - camera.Min.Y) * zoom)),
+	}
+}
+
+// CenterOn returns a copy of the viewport with its camera moved so that it is centered on ‘p’ (in world coordinates).
+func (receiver Viewport) CenterOn(p image.Point) Viewport {
+	camera := receiver.Camera.Canon()
+
+	min := image.Point{
+		X: p.X - camera.Dx()/2,
+		Y: p.Y - camera.Dy()/2,
+	}
+
+	receiver.Camera = camera.Add(min.Sub(camera.Min))
+
+	return receiver
+}
+
+// internalViewportFrame is the image returned from Viewport.Frame.
+//
+// ‘world’ is the world relocated so that the top-left corner of the camera is at (0,0).
+type internalViewportFrame struct {
+	world  internalImage
+	screen image.Rectangle
+	zoom   float64
+}
+
+func (receiver internalViewportFrame) At(x, y int) color.Color {
+	if nil == receiver.world.img {
+		return color.Transparent
+	}
+
+	if !(image.Point{x,y}).In(receiver.screen) {
+		return color.Transparent
+	}
+
+	x = int(math.Floor(float64(x) / receiver.zoom))
+	y = int(math.Floor(float64(y) / receiver.zoom))
+
+	return receiver.world.At(x,y)
+}
+
+func (receiver internalViewportFrame) Bounds() image.Rectangle {
+	return receiver.screen
+}
+
+func (receiver internalViewportFrame) ColorModel() color.Model {
+	if nil == receiver.world.img {
+		return color.RGBA64Model
+	}
+
+	return receiver.world.img.ColorModel()
+}
@@ -0,0 +1,140 @@
+package imagerelocate_test
+
+import (
+	"github.com/reiver/go-imagerelocate"
+
+	"image"
+	"image/color"
+
+	"testing"
+)
+
+func TestViewport(t *testing.T) {
+
+	world := image.NewRGBA(image.Rect(-10,-10, 10,10))
+	world.Set(3,4, color.RGBA{R:255, A:255})
+
+	tests := []struct{
+		Viewport imagerelocate.Viewport
+		ScreenPoint image.Point
+		ExpectedBounds image.Rectangle
+		ExpectedWorldPoint image.Point
+	}{
+		{
+			Viewport: imagerelocate.Viewport{
+				World: world,
+				Camera: image.Rect(0,0, 8,6),
+			},
+			ScreenPoint: image.Pt(3,4),
+			ExpectedBounds: image.Rect(0,0, 8,6),
+			ExpectedWorldPoint: image.Pt(3,4),
+		},
+		{
+			Viewport: imagerelocate.Viewport{
+				World: world,
+				Camera: image.Rect(2,2, 6,6),
+			},
+			ScreenPoint: image.Pt(1,2),
+			ExpectedBounds: image.Rect(0,0, 4,4),
+			ExpectedWorldPoint: image.Pt(3,4),
+		},
+		{
+			Viewport: imagerelocate.Viewport{
+				World: world,
+				Camera: image.Rect(2,2, 6,6),
+				Zoom: 2,
+			},
+			ScreenPoint: image.Pt(3,5),
+			ExpectedBounds: image.Rect(0,0, 8,8),
+			ExpectedWorldPoint: image.Pt(3,4),
+		},
+		{
+			Viewport: imagerelocate.Viewport{
+				World: world,
+				Camera: image.Rect(5,4, 15,14),
+				ClampToWorld: true,
+			},
+			ScreenPoint: image.Pt(3,4),
+			ExpectedBounds: image.Rect(0,0, 10,10),
+			ExpectedWorldPoint: image.Pt(3,4),
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		if expected, actual := test.ExpectedBounds, test.Viewport.Bounds(); expected != actual {
+			t.Errorf("For test #%d, the actual bounds are not what was expected.", testNumber)
+			t.Logf("EXPECTED: %#v", expected)
+			t.Logf("ACTUAL:   %#v", actual)
+			continue
+		}
+
+		if expected, actual := test.ExpectedWorldPoint, test.Viewport.ScreenToWorld(test.ScreenPoint); expected != actual {
+			t.Errorf("For test #%d, the actual world point is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %#v", expected)
+			t.Logf("ACTUAL:   %#v", actual)
+			continue
+		}
+
+		if expected, actual := test.ExpectedWorldPoint, test.Viewport.ScreenToWorld(test.Viewport.WorldToScreen(test.ExpectedWorldPoint)); expected != actual {
+			t.Errorf("For test #%d, the actual round-tripped world point is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %#v", expected)
+			t.Logf("ACTUAL:   %#v", actual)
+			continue
+		}
+
+		if expected, actual := world.At(3,4), test.Viewport.At(test.ScreenPoint.X, test.ScreenPoint.Y); expected != actual {
+			t.Errorf("For test #%d, the actual color is not what was expected.", testNumber)
+			t.Logf("EXPECTED: %#v", expected)
+			t.Logf("ACTUAL:   %#v", actual)
+			continue
+		}
+	}
+}
+
+func TestViewport_Frame(t *testing.T) {
+
+	world := image.NewRGBA(image.Rect(-10,-10, 10,10))
+	for y:=-10; y<10; y++ {
+		for x:=-10; x<10; x++ {
+			world.Set(x,y, color.RGBA{R:uint8(x), G:uint8(y), A:255})
+		}
+	}
+
+	viewport := imagerelocate.Viewport{
+		World: world,
+		Camera: image.Rect(5,4, 15,14),
+		Zoom: 1.5,
+		ClampToWorld: true,
+	}
+
+	frame := viewport.Frame()
+
+	if expected, actual := viewport.Bounds(), frame.Bounds(); expected != actual {
+		t.Errorf("The actual frame bounds are not what was expected.")
+		t.Logf("EXPECTED: %#v", expected)
+		t.Logf("ACTUAL:   %#v", actual)
+	}
+
+	bounds := viewport.Bounds()
+	for y:=bounds.Min.Y-1; y<bounds.Max.Y+1; y++ {
+		for x:=bounds.Min.X-1; x<bounds.Max.X+1; x++ {
+			if expected, actual := viewport.At(x,y), frame.At(x,y); expected != actual {
+				t.Errorf("The actual color at (%d,%d) is not what was expected.", x, y)
+				t.Logf("EXPECTED: %#v", expected)
+				t.Logf("ACTUAL:   %#v", actual)
+				return
+			}
+		}
+	}
+
+	// Looking up a pixel should not allocate.
+	uniform := imagerelocate.Viewport{
+		World: image.NewUniform(color.White),
+		Camera: image.Rect(0,0, 640,480),
+		Zoom: 2,
+	}
+	if allocs := testing.AllocsPerRun(100, func(){ uniform.At(100,100) }); 0 != allocs {
+		t.Errorf("Expected .At() to not allocate, but it allocated %v times.", allocs)
+	}
+}
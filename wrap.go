@@ -6,10 +6,44 @@ import (
 
 // Wrap returns an image.Image that is just like ‘img’,
 // except relocated to (‘x’, ‘y’).
+//
+// If ‘img’ is unbounded (see IsUnbounded), then the returned image is also unbounded,
+// and its bounds are left as they are.
+//
+// If ‘img’ is an image.PalettedImage (such as *image.Paletted), then the returned image is also an image.PalettedImage.
+//
+// If ‘img’ is an *image.YCbCr or *image.NYCbCrA (such as what image/jpeg decodes), and (‘x’, ‘y’) keeps the
+// chroma samples aligned with the luma samples, then the returned image is of that same type, sharing the pixel
+// data of ‘img’. Otherwise the returned image is a view that keeps the chroma subsampling of ‘img’ correct.
+//
+// If ‘img’ is a *Buffer, then the returned image is a *Buffer (sharing its pixels) with its origin moved.
 func Wrap(x,y int, img image.Image) image.Image{
-	return internalImage{
+	switch casted := img.(type) {
+	case *image.YCbCr:
+		return wrapYCbCr(x,y, casted)
+	case *image.NYCbCrA:
+		return wrapNYCbCrA(x,y, casted)
+	case relocatable:
+		return casted.relocate(x,y)
+	}
+
+	if IsUnbounded(img) {
+		return internalUnboundedImage{
+			x:x,
+			y:y,
+			img:img,
+		}
+	}
+
+	relocated := internalImage{
 		x:x,
 		y:y,
 		img:img,
 	}
+
+	if _, casted := img.(image.PalettedImage); casted {
+		return internalPalettedImage{relocated}
+	}
+
+	return relocated
 }
@@ -0,0 +1,87 @@
+package imagerelocate
+
+import (
+	"image"
+)
+
+// chromaAlignment returns how many luma samples, horizontally and vertically, share a single chroma sample,
+// for the subsample ratio.
+func chromaAlignment(ratio image.YCbCrSubsampleRatio) (int, int) {
+	switch ratio {
+	case image.YCbCrSubsampleRatio422:
+		return 2, 1
+	case image.YCbCrSubsampleRatio420:
+		return 2, 2
+	case image.YCbCrSubsampleRatio440:
+		return 1, 2
+	case image.YCbCrSubsampleRatio411:
+		return 4, 1
+	case image.YCbCrSubsampleRatio410:
+		return 4, 2
+	default:
+		return 1, 1
+	}
+}
+
+// chromaAligned returns whether an *image.YCbCr with bounds ‘rect’ and subsample ratio ‘ratio’
+// can be relocated by (‘dx’,‘dy’) just by rewriting its .Rect — i.e., without the chroma samples
+// no longer lining up with the luma samples.
+//
+// The offset has to be a multiple of the subsampling. And, because *image.YCbCr finds chroma samples
+// using integer division (which rounds towards zero), the bounds cannot be moved to, or from, negative coordinates.
+func chromaAligned(rect image.Rectangle, ratio image.YCbCrSubsampleRatio, dx, dy int) bool {
+	alignX, alignY := chromaAlignment(ratio)
+
+	if 0 != dx%alignX || 0 != dy%alignY {
+		return false
+	}
+
+	moved := rect.Add(image.Point{dx,dy})
+
+	if 1 < alignX && 0 != dx && (rect.Min.X < 0 || moved.Min.X < 0) {
+		return false
+	}
+	if 1 < alignY && 0 != dy && (rect.Min.Y < 0 || moved.Min.Y < 0) {
+		return false
+	}
+
+	return true
+}
+
+// wrapYCbCr relocates an *image.YCbCr.
+//
+// When the offset is chroma-aligned, this is zero-copy — a new *image.YCbCr sharing the same pixel data with a rewritten .Rect is returned.
+// Otherwise a (correct, but slower) relocated view is returned.
+func wrapYCbCr(x,y int, img *image.YCbCr) image.Image {
+	if !chromaAligned(img.Rect, img.SubsampleRatio, x,y) {
+		return internalImage{
+			x:x,
+			y:y,
+			img:img,
+		}
+	}
+
+	relocated := *img
+	relocated.Rect = img.Rect.Add(image.Point{x,y})
+
+	return &relocated
+}
+
+// wrapNYCbCrA relocates an *image.NYCbCrA.
+//
+// When the offset is chroma-aligned, this is zero-copy — a new *image.NYCbCrA sharing the same pixel data with a rewritten .Rect is returned.
+// Otherwise a (correct, but slower) relocated view is returned.
+func wrapNYCbCrA(x,y int, img *image.NYCbCrA) image.Image {
+	if !chromaAligned(img.Rect, img.SubsampleRatio, x,y) {
+		return internalImage{
+			x:x,
+			y:y,
+			img:img,
+		}
+	}
+
+	relocated := *img
+	relocated.Rect = img.Rect.Add(image.Point{x,y})
+
+	return &relocated
+}
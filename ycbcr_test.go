@@ -0,0 +1,140 @@
+package imagerelocate_test
+
+import (
+	"github.com/reiver/go-imagerelocate"
+
+	"image"
+	"image/draw"
+
+	"testing"
+)
+
+func TestWrap_ycbcr(t *testing.T) {
+
+	tests := []struct{
+		Ratio image.YCbCrSubsampleRatio
+		Alpha bool
+		DX int
+		DY int
+		ExpectZeroCopy bool
+	}{
+		{
+			Ratio: image.YCbCrSubsampleRatio420,
+			DX: 2,
+			DY: 4,
+			ExpectZeroCopy: true,
+		},
+		{
+			Ratio: image.YCbCrSubsampleRatio420,
+			DX: 1,
+			DY: 1,
+		},
+		{
+			Ratio: image.YCbCrSubsampleRatio420,
+			DX: -2,
+			DY: 0,
+		},
+		{
+			Ratio: image.YCbCrSubsampleRatio422,
+			DX: 2,
+			DY: 1,
+			ExpectZeroCopy: true,
+		},
+		{
+			Ratio: image.YCbCrSubsampleRatio411,
+			DX: 2,
+			DY: 0,
+		},
+		{
+			Ratio: image.YCbCrSubsampleRatio444,
+			DX: -3,
+			DY: 5,
+			ExpectZeroCopy: true,
+		},
+		{
+			Ratio: image.YCbCrSubsampleRatio420,
+			Alpha: true,
+			DX: 2,
+			DY: 4,
+			ExpectZeroCopy: true,
+		},
+		{
+			Ratio: image.YCbCrSubsampleRatio420,
+			Alpha: true,
+			DX: 1,
+			DY: 1,
+		},
+		{
+			Ratio: image.YCbCrSubsampleRatio422,
+			Alpha: true,
+			DX: 1,
+			DY: 0,
+		},
+		{
+			Ratio: image.YCbCrSubsampleRatio444,
+			Alpha: true,
+			DX: -3,
+			DY: 5,
+			ExpectZeroCopy: true,
+		},
+	}
+
+	for testNumber, test := range tests {
+
+		var src image.Image
+
+		var ycbcr *image.YCbCr
+		if test.Alpha {
+			nycbcra := image.NewNYCbCrA(image.Rect(0,0, 9,7), test.Ratio)
+			for i := range nycbcra.A {
+				nycbcra.A[i] = uint8(255 - i*3)
+			}
+			ycbcr = &nycbcra.YCbCr
+			src = nycbcra
+		} else {
+			ycbcr = image.NewYCbCr(image.Rect(0,0, 9,7), test.Ratio)
+			src = ycbcr
+		}
+		for i := range ycbcr.Y {
+			ycbcr.Y[i] = uint8(i*7)
+		}
+		for i := range ycbcr.Cb {
+			ycbcr.Cb[i] = uint8(i*31)
+			ycbcr.Cr[i] = uint8(255 - i*17)
+		}
+
+		img := imagerelocate.Wrap(test.DX, test.DY, src)
+
+		var casted bool
+		if test.Alpha {
+			_, casted = img.(*image.NYCbCrA)
+		} else {
+			_, casted = img.(*image.YCbCr)
+		}
+		if test.ExpectZeroCopy != casted {
+			t.Errorf("For test #%d, whether the relocated image is of the same type (%T) is not what was expected.", testNumber, src)
+			t.Logf("EXPECTED: %t", test.ExpectZeroCopy)
+			t.Logf("ACTUAL:   %t", casted)
+			continue
+		}
+
+		if expected, actual := src.Bounds().Add(image.Pt(test.DX, test.DY)), img.Bounds(); expected != actual {
+			t.Errorf("For test #%d, the actual bounds are not what was expected.", testNumber)
+			t.Logf("EXPECTED: %#v", expected)
+			t.Logf("ACTUAL:   %#v", actual)
+			continue
+		}
+
+		// Materialize both, and compare.
+		expected := image.NewRGBA(src.Bounds())
+		draw.Draw(expected, expected.Rect, src, src.Bounds().Min, draw.Src)
+
+		actual := image.NewRGBA(src.Bounds())
+		draw.Draw(actual, actual.Rect, img, img.Bounds().Min, draw.Src)
+
+		if string(expected.Pix) != string(actual.Pix) {
+			t.Errorf("For test #%d, the actual materialized pixels are not what was expected.", testNumber)
+			continue
+		}
+	}
+}